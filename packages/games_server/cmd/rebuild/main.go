@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// rebuild rescores team hole results from the raw scores. Run it after
+// changing format rules in code; the server fills rounds that have no
+// results when it starts.
+func main() {
+	tournamentID := flag.Int("tournament", 0, "rebuild a single tournament")
+	roundID := flag.Int("round", 0, "rebuild a single round")
+	flag.Parse()
+
+	dbPath := os.Getenv("DB_PATH")
+	if dbPath == "" {
+		dbPath = "golf.db"
+	}
+	sqlDB, err := store.New(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer sqlDB.Close()
+
+	if err := store.InitSchema(sqlDB); err != nil {
+		log.Fatalf("Failed to init schema: %v", err)
+	}
+
+	s := store.NewStore(sqlDB)
+	ctx := context.Background()
+
+	if *roundID != 0 {
+		n, err := game.RebuildRoundResults(ctx, s, *roundID)
+		if err != nil {
+			log.Fatalf("Failed to rebuild round %d: %v", *roundID, err)
+		}
+		log.Printf("[INFO] Rebuilt round %d: %d hole results", *roundID, n)
+		return
+	}
+
+	tournaments, err := s.GetAllTournaments()
+	if err != nil {
+		log.Fatalf("Failed to load tournaments: %v", err)
+	}
+	for _, t := range tournaments {
+		if *tournamentID != 0 && t.ID != *tournamentID {
+			continue
+		}
+		rounds, err := s.GetTournamentRounds(t.ID)
+		if err != nil {
+			log.Fatalf("Failed to load rounds for tournament %d: %v", t.ID, err)
+		}
+		for _, r := range rounds {
+			n, err := game.RebuildRoundResults(ctx, s, r.ID)
+			if err != nil {
+				log.Fatalf("Failed to rebuild round %d: %v", r.ID, err)
+			}
+			log.Printf("[INFO] Rebuilt tournament %d round %d: %d hole results", t.ID, r.ID, n)
+		}
+	}
+}
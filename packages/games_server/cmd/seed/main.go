@@ -48,17 +48,18 @@ func main() {
 	// ------------------------
 	formats := []struct {
 		Name          string
+		Code          string
 		Description   string
 		IsTeamScoring bool
 	}{
-		{"Scramble", "Teams play from the best shot selected after every stroke.", true},
-		{"Shamble", "Teams play from the best drive, then each player plays their own ball into the hole.", false},
-		{"2-Man Best Ball (Combined)", "Teams of 4. The top two scores on the hole combined.", false},
-		{"Best Ball", "The lowest score on the hole counts as the team score.", false},
-		{"Alternate Shot (Foursomes)", "Teammates take turns hitting the same ball until holed.", true},
-		{"Individual Stroke Play", "Standard individual scoring. Every stroke counts.", false},
-		{"Match Play", "Scoring is by hole won, lost, or halved, not total strokes.", false},
-		{"Combined Score", "The sum of all net scores of team members counts as the team score.", true},
+		{"Scramble", "scramble", "Teams play from the best shot selected after every stroke.", true},
+		{"Shamble", "shamble", "Teams play from the best drive, then each player plays their own ball into the hole.", false},
+		{"2-Man Best Ball (Combined)", "two_best_balls", "Teams of 4. The top two scores on the hole combined.", false},
+		{"Best Ball", "best_ball", "The lowest score on the hole counts as the team score.", false},
+		{"Alternate Shot (Foursomes)", "alternate_shot", "Teammates take turns hitting the same ball until holed.", true},
+		{"Individual Stroke Play", "stroke_play", "Standard individual scoring. Every stroke counts.", false},
+		{"Match Play", "match_play", "Scoring is by hole won, lost, or halved, not total strokes.", false},
+		{"Combined Score", "combined", "The sum of all net scores of team members counts as the team score.", true},
 	}
 
 	formatIDs := make(map[string]int64)
@@ -66,9 +67,9 @@ func main() {
 	for _, f := range formats {
 		log.Printf("[DEBUG] Inserting format: %s", f.Name)
 		res, err := tx.Exec(`
-			INSERT INTO tournament_formats (name, description, is_team_scoring, created_at)
-			VALUES (?, ?, ?, ?)
-		`, f.Name, f.Description, f.IsTeamScoring, nowStr)
+			INSERT INTO tournament_formats (name, code, description, is_team_scoring, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, f.Name, f.Code, f.Description, f.IsTeamScoring, nowStr)
 		if err != nil {
 			log.Printf("[ERROR] Seeding format %s: %v", f.Name, err)
 			tx.Rollback()
@@ -96,8 +97,8 @@ func main() {
 
 	// Seed Course Tees
 	res, err = tx.Exec(`
-		INSERT INTO course_tees (course_id, name, created_at) VALUES (?, ?, ?)
-	`, courseID, "Mens", nowStr)
+		INSERT INTO course_tees (course_id, name, slope, course_rating, par, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	`, courseID, "Mens", 128, 71.6, 72, nowStr)
 	if err != nil {
 		log.Printf("[ERROR] Seeding course tees: %v", err)
 		tx.Rollback()
@@ -0,0 +1,33 @@
+-- Stable scoring codes for tournament formats. The code selects the scoring
+-- rules in internal/game, so format names can be edited freely.
+ALTER TABLE tournament_formats ADD COLUMN code TEXT;
+
+-- Backfill existing rows from the names the seed has always used. Names
+-- aren't unique, so only the first format matching a name gets its code; the
+-- rest keep none and score with the default format.
+UPDATE tournament_formats SET code = 'scramble' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%scramble%'
+);
+UPDATE tournament_formats SET code = 'shamble' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%shamble%'
+);
+UPDATE tournament_formats SET code = 'alternate_shot' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%alternate shot%'
+);
+UPDATE tournament_formats SET code = 'two_best_balls' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%2-man%'
+);
+UPDATE tournament_formats SET code = 'combined' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%combined score%'
+);
+UPDATE tournament_formats SET code = 'best_ball' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%best ball%' AND lower(name) NOT LIKE '%2-man%'
+);
+UPDATE tournament_formats SET code = 'stroke_play' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%stroke play%'
+);
+UPDATE tournament_formats SET code = 'match_play' WHERE id = (
+    SELECT MIN(id) FROM tournament_formats WHERE code IS NULL AND lower(name) LIKE '%match play%'
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tournament_formats_code ON tournament_formats (code);
@@ -0,0 +1,20 @@
+-- Match play pairings. Team matches leave the player columns NULL;
+-- singles matches set both player ids (and their teams).
+CREATE TABLE round_matches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tournament_round_id INTEGER NOT NULL,
+    match_number INTEGER NOT NULL,
+    side_a_team_id INTEGER NOT NULL,
+    side_b_team_id INTEGER NOT NULL,
+    side_a_player_id INTEGER,
+    side_b_player_id INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tournament_round_id, match_number),
+    FOREIGN KEY (tournament_round_id) REFERENCES tournament_rounds (id),
+    FOREIGN KEY (side_a_team_id) REFERENCES teams (id),
+    FOREIGN KEY (side_b_team_id) REFERENCES teams (id),
+    FOREIGN KEY (side_a_player_id) REFERENCES players (id),
+    FOREIGN KEY (side_b_player_id) REFERENCES players (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_round_matches_round ON round_matches (tournament_round_id);
@@ -0,0 +1,15 @@
+-- Optional per-format point table overrides for points-based formats, stored
+-- as JSON text, e.g. {"birdie": 3, "par": 2, "bogey": 1}. Missing keys keep the defaults.
+ALTER TABLE tournament_formats ADD COLUMN point_values TEXT;
+
+INSERT INTO tournament_formats (name, code, description, is_team_scoring)
+SELECT 'Stableford', 'stableford', 'Points per hole based on net score relative to par. Highest total wins.', 0
+WHERE NOT EXISTS (SELECT 1 FROM tournament_formats WHERE code = 'stableford');
+
+INSERT INTO tournament_formats (name, code, description, is_team_scoring)
+SELECT 'Stableford (Gross)', 'stableford_gross', 'Points per hole based on gross score relative to par. Highest total wins.', 0
+WHERE NOT EXISTS (SELECT 1 FROM tournament_formats WHERE code = 'stableford_gross');
+
+INSERT INTO tournament_formats (name, code, description, is_team_scoring)
+SELECT 'Modified Stableford', 'modified_stableford', 'Aggressive points table that rewards birdies and penalizes bogeys. Highest total wins.', 0
+WHERE NOT EXISTS (SELECT 1 FROM tournament_formats WHERE code = 'modified_stableford');
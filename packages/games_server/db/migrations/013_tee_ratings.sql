@@ -0,0 +1,5 @@
+-- World Handicap System ratings per tee set. A NULL course rating or par
+-- falls back to the par of the holes being played.
+ALTER TABLE course_tees ADD COLUMN slope INTEGER NOT NULL DEFAULT 113;
+ALTER TABLE course_tees ADD COLUMN course_rating REAL;
+ALTER TABLE course_tees ADD COLUMN par INTEGER;
@@ -0,0 +1,4 @@
+-- Team handicap weights for formats that play a single team ball, keyed by
+-- team size and applied to the team's course handicaps lowest first, e.g.
+-- {"2": [0.35, 0.15]}. NULL uses the format's default weights.
+ALTER TABLE tournament_formats ADD COLUMN team_handicap TEXT;
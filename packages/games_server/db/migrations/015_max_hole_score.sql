@@ -0,0 +1,4 @@
+-- Maximum score that counts on a hole: 'net_double_bogey', 'double_par' or
+-- 'fixed' (max_score_value strokes). NULL counts every stroke.
+ALTER TABLE tournaments ADD COLUMN max_score_rule TEXT;
+ALTER TABLE tournaments ADD COLUMN max_score_value INTEGER;
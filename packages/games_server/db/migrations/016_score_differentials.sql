@@ -0,0 +1,20 @@
+-- Score differentials posted from completed rounds, used to calculate
+-- handicap indexes. handicap_index is the player's index after the score
+-- was posted (NULL until there are enough scores).
+CREATE TABLE score_differentials (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL,
+    tournament_round_id INTEGER NOT NULL,
+    adjusted_gross INTEGER NOT NULL,
+    course_rating REAL NOT NULL,
+    slope INTEGER NOT NULL,
+    differential REAL NOT NULL,
+    handicap_index REAL,
+    played_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (player_id, tournament_round_id),
+    FOREIGN KEY (player_id) REFERENCES players (id),
+    FOREIGN KEY (tournament_round_id) REFERENCES tournament_rounds (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_score_differentials_player ON score_differentials (player_id, played_at);
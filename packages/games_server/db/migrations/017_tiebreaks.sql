@@ -0,0 +1,16 @@
+-- Tiebreak rules per tournament as JSON, e.g.
+-- {"method": "countback", "holes": [9, 6, 3, 1]}. NULL uses countback.
+ALTER TABLE tournaments ADD COLUMN tiebreak TEXT;
+
+-- Finishing order of teams that went to a playoff. Lower places finish
+-- ahead of the teams they tied with.
+CREATE TABLE playoff_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tournament_id INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    place INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tournament_id, team_id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id),
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+);
@@ -0,0 +1,16 @@
+-- Each team's counting score per hole, maintained as scores are submitted so
+-- leaderboards aggregate instead of rescoring every round. Scores are
+-- relative to par, or points for points formats. Existing rounds are filled
+-- by the rebuild command (make rebuild-results).
+CREATE TABLE team_hole_results (
+    tournament_round_id INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    course_hole_id INTEGER NOT NULL,
+    hole_number INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tournament_round_id, team_id, course_hole_id),
+    FOREIGN KEY (tournament_round_id) REFERENCES tournament_rounds (id) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE CASCADE,
+    FOREIGN KEY (course_hole_id) REFERENCES course_holes (id)
+);
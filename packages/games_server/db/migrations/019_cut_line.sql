@@ -0,0 +1,17 @@
+-- Cut rule per tournament as JSON, e.g. {"afterRound": 2, "top": 10} for the
+-- top 10 and ties, or {"afterRound": 2, "withinStrokes": 8}. NULL plays
+-- every team through.
+ALTER TABLE tournaments ADD COLUMN cut_rule TEXT;
+
+-- Teams that missed the cut, recorded when the cut round is completed.
+-- They cannot post scores in rounds after after_round.
+CREATE TABLE missed_cuts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tournament_id INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    after_round INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tournament_id, team_id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id),
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+);
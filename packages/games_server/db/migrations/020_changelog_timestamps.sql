@@ -0,0 +1,54 @@
+-- Record when each change was made so leaderboards can be replayed as of a
+-- point in time. Changes logged before this migration have no timestamp and
+-- sort before every timestamped change.
+ALTER TABLE changelog ADD COLUMN created_at INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_changelog_ns_created ON changelog (namespace, created_at);
+
+DROP TRIGGER IF EXISTS entities_ai;
+CREATE TRIGGER entities_ai AFTER INSERT ON entities
+BEGIN
+	UPDATE meta SET value = value + 1 WHERE key = 'version';
+	INSERT INTO changelog (namespace, version, client_id, entity_type, entity_id, op, data, created_at)
+	SELECT 
+		NEW.namespace,
+		(SELECT value FROM meta WHERE key = 'version'),
+		COALESCE((SELECT client_id FROM _tx_context LIMIT 1), 'server'),
+		NEW.type,
+		NEW.entity_id,
+		'upsert',
+		NEW.data,
+		strftime('%s', 'now') * 1000;
+END;
+
+DROP TRIGGER IF EXISTS entities_au;
+CREATE TRIGGER entities_au AFTER UPDATE ON entities
+BEGIN
+	UPDATE meta SET value = value + 1 WHERE key = 'version';
+	INSERT INTO changelog (namespace, version, client_id, entity_type, entity_id, op, data, created_at)
+	SELECT 
+		NEW.namespace,
+		(SELECT value FROM meta WHERE key = 'version'),
+		COALESCE((SELECT client_id FROM _tx_context LIMIT 1), 'server'),
+		NEW.type,
+		NEW.entity_id,
+		'upsert',
+		NEW.data,
+		strftime('%s', 'now') * 1000;
+END;
+
+DROP TRIGGER IF EXISTS entities_ad;
+CREATE TRIGGER entities_ad AFTER DELETE ON entities
+BEGIN
+	UPDATE meta SET value = value + 1 WHERE key = 'version';
+	INSERT INTO changelog (namespace, version, client_id, entity_type, entity_id, op, data, created_at)
+	SELECT 
+		OLD.namespace,
+		(SELECT value FROM meta WHERE key = 'version'),
+		COALESCE((SELECT client_id FROM _tx_context LIMIT 1), 'server'),
+		OLD.type,
+		OLD.entity_id,
+		'delete',
+		NULL,
+		strftime('%s', 'now') * 1000;
+END;
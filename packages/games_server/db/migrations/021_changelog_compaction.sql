@@ -0,0 +1,9 @@
+-- Compaction keeps only the latest op per entity up to a namespace's
+-- horizon. Changes before the horizon can no longer be synced or replayed
+-- one by one.
+CREATE TABLE IF NOT EXISTS changelog_horizons (
+    namespace INTEGER PRIMARY KEY,
+    version INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_changelog_entity ON changelog (namespace, entity_type, entity_id, version);
@@ -0,0 +1,25 @@
+-- Sync clients number their mutations in order and tag each with an ID of
+-- their own. Results are kept for a window so a retried batch gets the same
+-- answers without applying twice. Clients pick their own IDs, so a client is
+-- only known within the tournament and player (0 for none) of its token.
+CREATE TABLE IF NOT EXISTS sync_clients (
+    tournament_id INTEGER NOT NULL,
+    player_id INTEGER NOT NULL,
+    client_id TEXT NOT NULL,
+    last_seq INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (tournament_id, player_id, client_id)
+);
+
+CREATE TABLE IF NOT EXISTS sync_mutations (
+    tournament_id INTEGER NOT NULL,
+    player_id INTEGER NOT NULL,
+    client_id TEXT NOT NULL,
+    mutation_id TEXT NOT NULL,
+    seq INTEGER,
+    result TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (tournament_id, player_id, client_id, mutation_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_mutations_created ON sync_mutations (created_at);
@@ -0,0 +1,11 @@
+-- The handicaps players played a round off, kept when a player's handicap
+-- changes after the round began so the round still scores as it was played.
+-- Rounds without a row here read the player's current handicap.
+CREATE TABLE round_handicaps (
+    tournament_round_id INTEGER NOT NULL,
+    player_id INTEGER NOT NULL,
+    handicap REAL,
+    PRIMARY KEY (tournament_round_id, player_id),
+    FOREIGN KEY (tournament_round_id) REFERENCES tournament_rounds (id) ON DELETE CASCADE,
+    FOREIGN KEY (player_id) REFERENCES players (id) ON DELETE CASCADE
+);
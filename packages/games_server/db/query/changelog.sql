@@ -0,0 +1,40 @@
+-- name: ClearTxContext :exec
+DELETE FROM _tx_context;
+
+-- name: GetScoreChanges :many
+SELECT version, entity_id, op, CAST(COALESCE(data, '') AS TEXT) AS data, created_at
+FROM changelog
+WHERE namespace = ? AND entity_type = 'score' AND version <= ?
+ORDER BY version;
+
+-- name: GetChangelogHorizon :one
+SELECT CAST(COALESCE(MAX(version), 0) AS INTEGER)
+FROM changelog_horizons
+WHERE namespace = ?;
+
+-- name: GetChangelogVersionAt :one
+SELECT CAST(COALESCE(MAX(version), 0) AS INTEGER)
+FROM changelog
+WHERE namespace = ? AND (created_at IS NULL OR created_at <= ?);
+
+-- name: PruneChangelog :execrows
+DELETE FROM changelog
+WHERE version <= (SELECT h.version FROM changelog_horizons h WHERE h.namespace = changelog.namespace)
+  AND EXISTS (
+    SELECT 1 FROM changelog n
+    WHERE n.namespace = changelog.namespace
+      AND n.entity_type = changelog.entity_type
+      AND n.entity_id = changelog.entity_id
+      AND n.version > changelog.version
+      AND n.version <= (SELECT h.version FROM changelog_horizons h WHERE h.namespace = changelog.namespace)
+  );
+
+-- name: SetChangelogHorizons :exec
+INSERT INTO changelog_horizons (namespace, version)
+SELECT namespace, MAX(version) FROM changelog
+WHERE created_at IS NULL OR created_at < ?
+GROUP BY namespace
+ON CONFLICT (namespace) DO UPDATE SET version = MAX(changelog_horizons.version, excluded.version);
+
+-- name: SetTxContext :exec
+INSERT INTO _tx_context (client_id) VALUES (?);
@@ -12,3 +12,15 @@ SELECT id, hole_number, par, handicap, yardage
 FROM course_holes 
 WHERE course_id = ?
 ORDER BY hole_number ASC;
+
+-- name: GetCourseTees :many
+SELECT id, course_id, name, slope, course_rating, par
+FROM course_tees
+WHERE course_id = ?
+ORDER BY id ASC;
+
+-- name: UpdateCourseTeeRating :one
+UPDATE course_tees
+SET slope = ?, course_rating = ?, par = ?
+WHERE id = ? AND course_id = ?
+RETURNING id, course_id, name, slope, course_rating, par;
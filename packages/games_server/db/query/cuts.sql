@@ -0,0 +1,26 @@
+-- name: GetMissedCuts :many
+SELECT *
+FROM missed_cuts
+WHERE tournament_id = ?
+ORDER BY team_id;
+
+-- name: InsertMissedCut :exec
+INSERT INTO missed_cuts (tournament_id, team_id, after_round)
+VALUES (?, ?, ?)
+ON CONFLICT (tournament_id, team_id) DO UPDATE SET after_round = excluded.after_round;
+
+-- name: DeleteMissedCuts :exec
+DELETE FROM missed_cuts WHERE tournament_id = ?;
+
+-- name: IsTeamCutFromRound :one
+SELECT COUNT(*)
+FROM missed_cuts mc
+JOIN tournament_rounds tr ON tr.tournament_id = mc.tournament_id
+WHERE tr.id = ? AND mc.team_id = ? AND tr.round_number > mc.after_round;
+
+-- name: IsPlayerCutFromRound :one
+SELECT COUNT(*)
+FROM missed_cuts mc
+JOIN tournament_rounds tr ON tr.tournament_id = mc.tournament_id
+JOIN players p ON p.team_id = mc.team_id
+WHERE tr.id = ? AND p.id = ? AND tr.round_number > mc.after_round;
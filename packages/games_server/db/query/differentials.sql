@@ -0,0 +1,43 @@
+-- name: UpsertScoreDifferential :exec
+INSERT INTO score_differentials (
+    player_id,
+    tournament_round_id,
+    adjusted_gross,
+    course_rating,
+    slope,
+    differential,
+    played_at
+)
+SELECT ?, tr.id, ?, ?, ?, ?, tr.date
+FROM tournament_rounds tr
+WHERE tr.id = ?
+ON CONFLICT (player_id, tournament_round_id) DO UPDATE SET
+    adjusted_gross = excluded.adjusted_gross,
+    course_rating = excluded.course_rating,
+    slope = excluded.slope,
+    differential = excluded.differential;
+
+-- name: GetPlayerDifferentials :many
+SELECT *
+FROM score_differentials
+WHERE player_id = ?
+ORDER BY played_at, id;
+
+-- name: GetRoundHandicaps :many
+SELECT player_id, handicap
+FROM round_handicaps
+WHERE tournament_round_id = ?;
+
+-- name: KeepRoundHandicaps :exec
+INSERT OR IGNORE INTO round_handicaps (tournament_round_id, player_id, handicap)
+SELECT tr.id, p.id, p.handicap
+FROM players p
+JOIN tournament_rounds tr ON tr.tournament_id = p.tournament_id
+WHERE p.id = ?
+  AND (
+    tr.status IN ('active', 'completed')
+    OR EXISTS (SELECT 1 FROM scores s WHERE s.tournament_round_id = tr.id)
+  );
+
+-- name: SetDifferentialHandicapIndex :exec
+UPDATE score_differentials SET handicap_index = ? WHERE id = ?;
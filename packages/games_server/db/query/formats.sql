@@ -1,5 +1,5 @@
 -- name: GetAllFormats :many
-SELECT id, name, description, is_team_scoring FROM tournament_formats ORDER BY name;
+SELECT id, name, code, description, is_team_scoring, point_values, team_handicap FROM tournament_formats ORDER BY name;
 
 -- name: GetTournamentFormats :many
 SELECT *
@@ -8,4 +8,4 @@ FROM
     JOIN tournament_rounds tr ON tf.id = tr.format_id
 WHERE
     tr.id = ?
-ORDER BY tr.date;
\ No newline at end of file
+ORDER BY tr.date;
@@ -0,0 +1,20 @@
+-- name: GetRoundMatches :many
+SELECT *
+FROM round_matches
+WHERE tournament_round_id = ?
+ORDER BY match_number;
+
+-- name: CreateRoundMatch :one
+INSERT INTO round_matches (
+    tournament_round_id,
+    match_number,
+    side_a_team_id,
+    side_b_team_id,
+    side_a_player_id,
+    side_b_player_id
+)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: DeleteRoundMatches :exec
+DELETE FROM round_matches WHERE tournament_round_id = ?;
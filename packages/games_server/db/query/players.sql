@@ -8,3 +8,13 @@ SELECT id, name, handicap, is_admin, created_at FROM players ORDER BY name;
 INSERT INTO players (name, handicap, is_admin, created_at, tournament_id, team_id, course_tees_id) 
 VALUES (?, ?, ?, ?, ?, ?, ?) 
 RETURNING id, name, handicap, is_admin, created_at;
+
+-- name: GetTournamentPlayers :many
+SELECT p.id, p.name, p.handicap, p.team_id, p.course_tees_id, ct.name AS tee_name
+FROM players p
+LEFT JOIN course_tees ct ON p.course_tees_id = ct.id
+WHERE p.tournament_id = ?
+ORDER BY p.name;
+
+-- name: UpdatePlayerHandicap :exec
+UPDATE players SET handicap = ? WHERE id = ?;
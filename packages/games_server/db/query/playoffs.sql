@@ -0,0 +1,13 @@
+-- name: GetPlayoffResults :many
+SELECT *
+FROM playoff_results
+WHERE tournament_id = ?
+ORDER BY place;
+
+-- name: UpsertPlayoffResult :exec
+INSERT INTO playoff_results (tournament_id, team_id, place)
+VALUES (?, ?, ?)
+ON CONFLICT (tournament_id, team_id) DO UPDATE SET place = excluded.place;
+
+-- name: DeletePlayoffResults :exec
+DELETE FROM playoff_results WHERE tournament_id = ?;
@@ -0,0 +1,28 @@
+-- name: GetRoundResults :many
+SELECT *
+FROM team_hole_results
+WHERE tournament_round_id = ?
+ORDER BY team_id, hole_number;
+
+-- name: GetUnscoredRounds :many
+SELECT DISTINCT s.tournament_round_id
+FROM scores s
+WHERE NOT EXISTS (
+    SELECT 1 FROM team_hole_results r WHERE r.tournament_round_id = s.tournament_round_id
+)
+ORDER BY s.tournament_round_id;
+
+-- name: UpsertTeamHoleResult :exec
+INSERT INTO team_hole_results (tournament_round_id, team_id, course_hole_id, hole_number, score)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (tournament_round_id, team_id, course_hole_id) DO UPDATE SET
+    hole_number = excluded.hole_number,
+    score = excluded.score,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteTeamHoleResult :exec
+DELETE FROM team_hole_results
+WHERE tournament_round_id = ? AND team_id = ? AND course_hole_id = ?;
+
+-- name: DeleteRoundResults :exec
+DELETE FROM team_hole_results WHERE tournament_round_id = ?;
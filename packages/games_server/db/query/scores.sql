@@ -1,3 +1,7 @@
+-- name: GetScoreUpdatedAt :one
+SELECT updated_at FROM entities
+WHERE type = 'score' AND entity_id = ?;
+
 -- name: GetTournamentScores :many
 SELECT s.id, s.tournament_round_id, s.player_id, s.team_id, s.course_hole_id, s.strokes, s.created_at, ch.hole_number
 FROM scores s
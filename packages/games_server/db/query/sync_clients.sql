@@ -0,0 +1,19 @@
+-- name: DeleteMutationResults :execrows
+DELETE FROM sync_mutations WHERE created_at < ?;
+
+-- name: GetClientSeq :one
+SELECT last_seq FROM sync_clients
+WHERE tournament_id = ? AND player_id = ? AND client_id = ?;
+
+-- name: GetMutationResult :one
+SELECT result FROM sync_mutations
+WHERE tournament_id = ? AND player_id = ? AND client_id = ? AND mutation_id = ? AND created_at >= ?;
+
+-- name: InsertMutationResult :exec
+INSERT OR REPLACE INTO sync_mutations (tournament_id, player_id, client_id, mutation_id, seq, result, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: SetClientSeq :exec
+INSERT INTO sync_clients (tournament_id, player_id, client_id, last_seq, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (tournament_id, player_id, client_id) DO UPDATE SET last_seq = excluded.last_seq, updated_at = excluded.updated_at;
@@ -5,6 +5,13 @@ WHERE
     tournament_id = ?
 ORDER BY round_number;
 
+-- name: GetCourseRounds :many
+SELECT *
+FROM tournament_rounds
+WHERE
+    course_id = ?
+ORDER BY tournament_id, round_number;
+
 -- name: GetTournamentRound :one
 SELECT tr.*, c.name AS course_name
 FROM
@@ -39,9 +46,11 @@ INSERT INTO
         format_id,
         date,
         name,
-        status
+        status,
+        awarded_handicap,
+        is_match_play
     )
-VALUES (?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING
     id,
     tournament_id,
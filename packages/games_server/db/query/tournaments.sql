@@ -6,7 +6,11 @@ SELECT
     complete,
     start_date,
     end_date,
-    created_at
+    created_at,
+    max_score_rule,
+    max_score_value,
+    tiebreak,
+    cut_rule
 FROM tournaments
 ORDER BY created_at DESC;
 
@@ -35,4 +39,17 @@ RETURNING
     complete,
     start_date,
     end_date,
-    created_at;
\ No newline at end of file
+    created_at,
+    max_score_rule,
+    max_score_value,
+    tiebreak,
+    cut_rule;
+
+-- name: SetTournamentMaxScore :exec
+UPDATE tournaments SET max_score_rule = ?, max_score_value = ? WHERE id = ?;
+
+-- name: SetTournamentTiebreak :exec
+UPDATE tournaments SET tiebreak = ? WHERE id = ?;
+
+-- name: SetTournamentCutRule :exec
+UPDATE tournaments SET cut_rule = ? WHERE id = ?;
\ No newline at end of file
@@ -0,0 +1,141 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// AdjustedHole is a player's score on a hole before and after the max hole score
+type AdjustedHole struct {
+	HoleNumber int  `json:"holeNumber"`
+	Par        int  `json:"par"`
+	Strokes    int  `json:"strokes"`            // Handicap strokes received
+	MaxScore   int  `json:"maxScore,omitempty"` // 0 when the hole is uncapped
+	Gross      int  `json:"gross"`
+	Adjusted   int  `json:"adjusted"`
+	Capped     bool `json:"capped"`
+}
+
+// AdjustedRound is a player's raw and adjusted gross for a round
+type AdjustedRound struct {
+	PlayerID       int            `json:"playerId"`
+	Name           string         `json:"name"`
+	TeamID         int            `json:"teamId"`
+	CourseHandicap int            `json:"courseHandicap"`
+	HolesPlayed    int            `json:"holesPlayed"`
+	Gross          int            `json:"gross"`
+	AdjustedGross  int            `json:"adjustedGross"`
+	Holes          []AdjustedHole `json:"holes"`
+}
+
+// AdjustRound applies a max hole score to a player's round. scores maps course
+// hole IDs to gross strokes. Strokes come off the full course handicap.
+func AdjustRound(holes []models.HoleData, scores map[int]int, courseHandicap int, maxScore *models.MaxScore) AdjustedRound {
+	ordered := make([]models.HoleData, len(holes))
+	copy(ordered, holes)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Number < ordered[j].Number
+	})
+
+	holeCount := HoleCount(ordered)
+	round := AdjustedRound{CourseHandicap: courseHandicap, Holes: []AdjustedHole{}}
+	for _, h := range ordered {
+		gross, ok := scores[h.ID]
+		if !ok {
+			continue
+		}
+
+		hole := NewHoleContext(h, holeCount, maxScore)
+		strokes := hole.Strokes(ScoreInput{Gross: gross, Handicap: float64(courseHandicap)}, 1.0)
+		adjusted := hole.Adjusted(gross, strokes)
+
+		round.Holes = append(round.Holes, AdjustedHole{
+			HoleNumber: h.Number,
+			Par:        h.Par,
+			Strokes:    strokes,
+			MaxScore:   MaxHoleScore(maxScore, h.Par, strokes),
+			Gross:      gross,
+			Adjusted:   adjusted,
+			Capped:     adjusted != gross,
+		})
+		round.HolesPlayed++
+		round.Gross += gross
+		round.AdjustedGross += adjusted
+	}
+	return round
+}
+
+// CalculateAdjustedScores returns the raw and adjusted gross of every player
+// who posted scores in a round, using the tournament's max hole score
+func CalculateAdjustedScores(ctx context.Context, db *store.Store, roundID int) ([]AdjustedRound, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	tournament, err := db.GetTournament(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == nil {
+		return nil, fmt.Errorf("tournament not found")
+	}
+
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return []AdjustedRound{}, nil
+	}
+
+	players, err := db.GetTournamentPlayers(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	handicaps, err := loadRoundHandicaps(db, *round, scoring, course, players)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := db.GetRoundScores(roundID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	playerScores := make(map[int]map[int]int)
+	for _, s := range scores {
+		if s.PlayerID == nil {
+			continue
+		}
+		if _, ok := playerScores[*s.PlayerID]; !ok {
+			playerScores[*s.PlayerID] = make(map[int]int)
+		}
+		playerScores[*s.PlayerID][s.CourseHoleID] = s.Strokes
+	}
+
+	results := []AdjustedRound{}
+	for _, p := range players {
+		holeScores, ok := playerScores[p.ID]
+		if !ok {
+			continue
+		}
+		adjusted := AdjustRound(course.Meta.Holes, holeScores, handicaps[p.ID].CourseHandicap, tournament.MaxScore)
+		adjusted.PlayerID = p.ID
+		adjusted.Name = p.Name
+		adjusted.TeamID = p.TeamID
+		results = append(results, adjusted)
+	}
+	return results, nil
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"context"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// CutLine is the score a team needed through the cut round to play on
+type CutLine struct {
+	AfterRound int  `json:"afterRound"`
+	Score      int  `json:"score"`
+	Applied    bool `json:"applied"` // false while the line is projected from scores so far
+}
+
+// cutScore is a team's score through the cut round
+type cutScore struct {
+	TeamID int
+	Score  int
+	Thru   int
+}
+
+// cutScores totals each team's stroke play rounds up to and including the
+// cut round
+func cutScores(entries []LeaderboardEntry, afterRound int) []cutScore {
+	scores := make([]cutScore, 0, len(entries))
+	for _, e := range entries {
+		cs := cutScore{TeamID: e.TeamID}
+		for _, r := range e.Rounds {
+			if r.RoundNumber <= afterRound {
+				cs.Score += r.Score
+				cs.Thru += r.Thru
+			}
+		}
+		scores = append(scores, cs)
+	}
+	return scores
+}
+
+// cutLineScore returns the score that makes the cut: the Nth best score for a
+// top N cut, so that ties with the Nth team play on, or the lead plus the
+// allowed strokes. ok is false until a team has played.
+func cutLineScore(rule models.CutRule, scores []cutScore, direction SortDirection) (int, bool) {
+	played := []int{}
+	for _, s := range scores {
+		if s.Thru > 0 {
+			played = append(played, s.Score)
+		}
+	}
+	if len(played) == 0 {
+		return 0, false
+	}
+	sort.Slice(played, func(i, j int) bool {
+		return direction.Better(played[i], played[j])
+	})
+
+	if rule.WithinStrokes != nil {
+		if direction == SortDescending {
+			return played[0] - *rule.WithinStrokes, true
+		}
+		return played[0] + *rule.WithinStrokes, true
+	}
+
+	n := rule.Top
+	if n > len(played) {
+		n = len(played)
+	}
+	return played[n-1], true
+}
+
+// makesCut reports whether a team's score through the cut round is on or
+// inside the line. Teams that did not play miss.
+func makesCut(s cutScore, line int, direction SortDirection) bool {
+	return s.Thru > 0 && (s.Score == line || direction.Better(s.Score, line))
+}
+
+// projectCut returns the cut line of a tournament leaderboard, or nil when
+// the tournament has no cut
+func projectCut(rule *models.CutRule, entries []LeaderboardEntry, direction SortDirection, applied bool) *CutLine {
+	if rule == nil {
+		return nil
+	}
+	line := &CutLine{AfterRound: rule.AfterRound, Applied: applied}
+	if score, ok := cutLineScore(*rule, cutScores(entries, rule.AfterRound), direction); ok {
+		line.Score = score
+	}
+	return line
+}
+
+// ApplyCut records the teams that missed a tournament's cut once its cut
+// round is complete. It returns the line the cut was made at, or nil when
+// the tournament has no cut.
+func ApplyCut(ctx context.Context, db *store.Store, cache *infra.CacheManager, tournamentID int) (*CutLine, error) {
+	t, err := db.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || t.Cut == nil {
+		return nil, nil
+	}
+
+	lb, err := CalculateLeaderboard(ctx, db, cache, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := cutScores(lb.Teams, t.Cut.AfterRound)
+	score, ok := cutLineScore(*t.Cut, scores, lb.SortDirection)
+	if !ok {
+		// Nobody has played the cut rounds
+		return nil, nil
+	}
+
+	missed := []int{}
+	for _, s := range scores {
+		if !makesCut(s, score, lb.SortDirection) {
+			missed = append(missed, s.TeamID)
+		}
+	}
+	if err := db.SetMissedCutsTx(tournamentID, t.Cut.AfterRound, missed); err != nil {
+		return nil, err
+	}
+
+	return &CutLine{AfterRound: t.Cut.AfterRound, Score: score, Applied: true}, nil
+}
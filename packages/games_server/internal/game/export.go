@@ -0,0 +1,260 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// ExportVersion is the version of the JSON export feeds. Fields are only ever
+// added within a version; anything else bumps it.
+const ExportVersion = 1
+
+// ExportRound is a round column of a leaderboard export
+type ExportRound struct {
+	RoundID       int           `json:"roundId"`
+	RoundNumber   int           `json:"roundNumber"`
+	Name          string        `json:"name"`
+	SortDirection SortDirection `json:"sortDirection"`
+}
+
+// ExportRow is a team's line of a leaderboard export, in the columns of a
+// results spreadsheet
+type ExportRow struct {
+	Position  string    `json:"position"`
+	TeamID    int       `json:"teamId"`
+	Team      string    `json:"team"`
+	Players   []string  `json:"players"`
+	Handicaps []float64 `json:"handicaps"` // Handicap index of each player, in player order
+	// Rounds holds the team's net strokes in each round, or points in points
+	// formats; nil for a round the team has not started
+	Rounds    []*int `json:"rounds"`
+	Total     int    `json:"total"`
+	ToPar     *int   `json:"toPar"` // nil when the board is ranked on points
+	Thru      int    `json:"thru"`
+	MissedCut bool   `json:"missedCut,omitempty"`
+}
+
+// LeaderboardExport is the export feed of a tournament or round leaderboard
+type LeaderboardExport struct {
+	Version       int           `json:"version"`
+	TournamentID  int           `json:"tournamentId"`
+	Tournament    string        `json:"tournament"`
+	RoundID       int           `json:"roundId,omitempty"`
+	Format        string        `json:"format"`
+	SortDirection SortDirection `json:"sortDirection"`
+	Rounds        []ExportRound `json:"rounds"`
+	Rows          []ExportRow   `json:"rows"`
+}
+
+// ExportLeaderboard builds the export of a tournament's leaderboard, or of a
+// single round's when roundID is set
+func ExportLeaderboard(ctx context.Context, db *store.Store, cache *infra.CacheManager, tournamentID int, roundID int) (*LeaderboardExport, error) {
+	t, err := db.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("tournament not found")
+	}
+
+	var lb *LeaderboardResponse
+	if roundID != 0 {
+		lb, err = CalculateRoundLeaderboard(ctx, db, cache, tournamentID, roundID)
+	} else {
+		lb, err = CalculateLeaderboard(ctx, db, cache, tournamentID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	export := &LeaderboardExport{
+		Version:       ExportVersion,
+		TournamentID:  tournamentID,
+		Tournament:    t.Name,
+		RoundID:       roundID,
+		Format:        lb.Format,
+		SortDirection: lb.SortDirection,
+		Rounds:        []ExportRound{},
+		Rows:          []ExportRow{},
+	}
+
+	// Every team carries the same stroke play rounds
+	if len(lb.Teams) > 0 {
+		for _, rs := range lb.Teams[0].Rounds {
+			round, err := db.GetTournamentRound(rs.RoundID)
+			if err != nil {
+				return nil, err
+			}
+			if round == nil {
+				continue
+			}
+			scoring, err := resolveRoundFormat(db, *round)
+			if err != nil {
+				return nil, err
+			}
+			export.Rounds = append(export.Rounds, ExportRound{
+				RoundID:       rs.RoundID,
+				RoundNumber:   rs.RoundNumber,
+				Name:          rs.Name,
+				SortDirection: scoring.SortDirection(),
+			})
+		}
+	}
+
+	players, err := db.GetTournamentPlayers(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	teamSize := make(map[int]int)
+	for _, p := range players {
+		teamSize[p.TeamID]++
+	}
+
+	// Net strokes are the par of the balls counted plus the score to par
+	parPlayed := make(map[int]map[int]int) // round -> team -> par of counted balls
+	for _, r := range export.Rounds {
+		if r.SortDirection == SortDescending {
+			continue
+		}
+		par, err := roundParPlayed(db, r.RoundID, teamSize)
+		if err != nil {
+			return nil, err
+		}
+		parPlayed[r.RoundID] = par
+	}
+
+	for _, e := range lb.Teams {
+		row := ExportRow{
+			Position:  e.PositionLabel,
+			TeamID:    e.TeamID,
+			Team:      e.TeamName,
+			Players:   []string{},
+			Handicaps: []float64{},
+			Rounds:    make([]*int, len(export.Rounds)),
+			Thru:      e.Thru,
+			MissedCut: e.MissedCut,
+		}
+		for _, p := range players {
+			if p.TeamID == e.TeamID {
+				row.Players = append(row.Players, p.Name)
+				row.Handicaps = append(row.Handicaps, p.Handicap)
+			}
+		}
+
+		for i, r := range export.Rounds {
+			for _, rs := range e.Rounds {
+				if rs.RoundID != r.RoundID || rs.Thru == 0 {
+					continue
+				}
+				v := rs.Score
+				if r.SortDirection != SortDescending {
+					v += parPlayed[r.RoundID][e.TeamID]
+				}
+				row.Rounds[i] = &v
+				row.Total += v
+			}
+		}
+
+		if lb.SortDirection != SortDescending {
+			toPar := e.Score
+			row.ToPar = &toPar
+		}
+		export.Rows = append(export.Rows, row)
+	}
+
+	return export, nil
+}
+
+// roundParPlayed sums, for each team, the par of every ball counted on the
+// holes the team has completed in a round
+func roundParPlayed(db *store.Store, roundID int, teamSize map[int]int) (map[int]int, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	par := make(map[int]int)
+	if course == nil {
+		return par, nil
+	}
+	holePar := make(map[int]int)
+	for _, h := range course.Meta.Holes {
+		holePar[h.ID] = h.Par
+	}
+
+	results, err := db.GetRoundResults(roundID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		par[r.TeamID] += holePar[r.CourseHoleID] * countedBalls(scoring, teamSize[r.TeamID])
+	}
+	return par, nil
+}
+
+// countedBalls returns how many balls make up a team's hole score
+func countedBalls(format ScoringFormat, teamSize int) int {
+	switch {
+	case format.Aggregation() == AggregateTeamBall || teamSize == 0:
+		return 1
+	case format.Aggregation() == AggregateBestN && format.ScoresToCount() > 0 && format.ScoresToCount() < teamSize:
+		return format.ScoresToCount()
+	default:
+		return teamSize
+	}
+}
+
+// ScorecardExport is the export feed of every team's scorecard for a round
+type ScorecardExport struct {
+	Version    int         `json:"version"`
+	RoundID    int         `json:"roundId"`
+	Round      string      `json:"round"`
+	Scorecards []Scorecard `json:"scorecards"`
+}
+
+// ExportScorecards builds the scorecard of every team in a round
+func ExportScorecards(ctx context.Context, db *store.Store, roundID int) (*ScorecardExport, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	teams, err := db.GetTeamsByTournament(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &ScorecardExport{
+		Version:    ExportVersion,
+		RoundID:    roundID,
+		Round:      round.Name,
+		Scorecards: []Scorecard{},
+	}
+	for _, tm := range teams {
+		teamID := tm.ID
+		card, err := BuildScorecard(ctx, db, roundID, &teamID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if card != nil {
+			export.Scorecards = append(export.Scorecards, *card)
+		}
+	}
+	return export, nil
+}
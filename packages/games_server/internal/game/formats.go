@@ -0,0 +1,250 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// Format codes stored on tournament_formats.code. These are stable
+// identifiers: renaming a format in the DB never changes how it scores.
+const (
+	FormatScramble      = "scramble"
+	FormatShamble       = "shamble"
+	FormatBestBall      = "best_ball"
+	FormatTwoBestBalls  = "two_best_balls"
+	FormatAlternateShot = "alternate_shot"
+	FormatStrokePlay    = "stroke_play"
+	FormatMatchPlay     = "match_play"
+	FormatCombined      = "combined"
+
+	FormatStableford         = "stableford"
+	FormatStablefordGross    = "stableford_gross"
+	FormatModifiedStableford = "modified_stableford"
+)
+
+// EntryMode describes who posts scores for a format.
+type EntryMode string
+
+const (
+	// EntryPerPlayer formats collect one score per player per hole.
+	EntryPerPlayer EntryMode = "player"
+	// EntryPerTeam formats collect a single score per team per hole.
+	EntryPerTeam EntryMode = "team"
+)
+
+// Aggregation is the rule used to turn a hole's entries into a team score.
+type Aggregation string
+
+const (
+	// AggregateBestN sums the lowest ScoresToCount net scores.
+	AggregateBestN Aggregation = "best_n"
+	// AggregateAll sums every net score on the team.
+	AggregateAll Aggregation = "all"
+	// AggregateTeamBall scores the single ball the team played.
+	AggregateTeamBall Aggregation = "team_ball"
+)
+
+// SortDirection is the order a format's totals rank in.
+type SortDirection string
+
+const (
+	// SortAscending ranks the lowest total first (strokes).
+	SortAscending SortDirection = "asc"
+	// SortDescending ranks the highest total first (points).
+	SortDescending SortDirection = "desc"
+)
+
+// Better reports whether total a ranks ahead of total b.
+func (d SortDirection) Better(a, b int) bool {
+	if d == SortDescending {
+		return a > b
+	}
+	return a < b
+}
+
+// ScoringFormat is the set of rules a tournament format scores by.
+type ScoringFormat interface {
+	Code() string
+	EntryMode() EntryMode
+	// ScoresToCount is the number of entries that count per hole (0 = all).
+	ScoresToCount() int
+	// DefaultAllowance is the handicap allowance used when a round does not set one.
+	DefaultAllowance() float64
+	Aggregation() Aggregation
+	SortDirection() SortDirection
+	// ScoreHole returns the team score for a single hole: strokes relative
+	// to par, or points for points-based formats.
+	ScoreHole(scores []ScoreInput, hole HoleContext) int
+}
+
+type strokeFormat struct {
+	code          string
+	entryMode     EntryMode
+	scoresToCount int
+	allowance     float64
+	aggregation   Aggregation
+	// teamWeights build the team handicap for team ball formats
+	teamWeights TeamHandicapWeights
+}
+
+func (f strokeFormat) Code() string                 { return f.code }
+func (f strokeFormat) EntryMode() EntryMode         { return f.entryMode }
+func (f strokeFormat) ScoresToCount() int           { return f.scoresToCount }
+func (f strokeFormat) DefaultAllowance() float64    { return f.allowance }
+func (f strokeFormat) Aggregation() Aggregation     { return f.aggregation }
+func (f strokeFormat) SortDirection() SortDirection { return SortAscending }
+
+func (f strokeFormat) TeamHandicap(courseHandicaps []int) int {
+	return f.teamWeights.TeamHandicap(courseHandicaps)
+}
+
+func (f strokeFormat) ScoreHole(scores []ScoreInput, hole HoleContext) int {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	// Team ball: one score per team, net of the team handicap. The team
+	// handicap weights already include the allowance. In case multiple
+	// scores exist (e.g. data error), take the lowest.
+	if f.aggregation == AggregateTeamBall {
+		best := 0
+		for i, s := range scores {
+			net := hole.Net(s, 1.0)
+			if i == 0 || net < best {
+				best = net
+			}
+		}
+		return best
+	}
+
+	netScores := make([]int, len(scores))
+	for i, s := range scores {
+		netScores[i] = hole.Net(s, hole.Allowance)
+	}
+
+	count := len(netScores)
+	if f.aggregation == AggregateBestN && f.scoresToCount > 0 && f.scoresToCount < count {
+		count = f.scoresToCount
+	}
+
+	sort.Ints(netScores)
+
+	total := 0
+	for i := 0; i < count; i++ {
+		total += netScores[i]
+	}
+	return total
+}
+
+var formatRegistry = map[string]ScoringFormat{}
+
+// RegisterFormat makes a scoring format available under its code.
+// Registering an existing code replaces it.
+func RegisterFormat(f ScoringFormat) {
+	formatRegistry[f.Code()] = f
+}
+
+// LookupFormat returns the scoring format registered for a format code.
+func LookupFormat(code string) (ScoringFormat, error) {
+	f, ok := formatRegistry[code]
+	if !ok {
+		return nil, fmt.Errorf("no scoring format registered for code %q", code)
+	}
+	return f, nil
+}
+
+// ResolveFormat returns the scoring rules for a stored format, applying any
+// per-format configuration such as point values.
+func ResolveFormat(f models.TournamentFormat) (ScoringFormat, error) {
+	scoring, err := LookupFormat(f.Code)
+	if err != nil {
+		return nil, fmt.Errorf("format %q: %w", f.Name, err)
+	}
+
+	if len(f.PointValues) > 0 {
+		pf, ok := scoring.(stablefordFormat)
+		if !ok {
+			return nil, fmt.Errorf("format %q: point values are not supported by %q", f.Name, f.Code)
+		}
+		if err := json.Unmarshal(f.PointValues, &pf.points); err != nil {
+			return nil, fmt.Errorf("format %q: invalid point values: %w", f.Name, err)
+		}
+		scoring = pf
+	}
+
+	if len(f.TeamHandicap) > 0 {
+		sf, ok := scoring.(strokeFormat)
+		if !ok || sf.aggregation != AggregateTeamBall {
+			return nil, fmt.Errorf("format %q: team handicaps are not supported by %q", f.Name, f.Code)
+		}
+		sf.teamWeights = TeamHandicapWeights{}
+		if err := json.Unmarshal(f.TeamHandicap, &sf.teamWeights); err != nil {
+			return nil, fmt.Errorf("format %q: invalid team handicap: %w", f.Name, err)
+		}
+		scoring = sf
+	}
+
+	return scoring, nil
+}
+
+// DefaultFormat is the code a format scores by when its own code is not
+// registered or its configuration is invalid, as rounds were scored before
+// formats carried codes
+const DefaultFormat = FormatBestBall
+
+// resolveFormatOrDefault returns the scoring rules for a stored format,
+// falling back to DefaultFormat's when they cannot be resolved. It returns
+// why it fell back, or "".
+func resolveFormatOrDefault(f models.TournamentFormat) (ScoringFormat, string) {
+	scoring, err := ResolveFormat(f)
+	if err != nil {
+		return formatRegistry[DefaultFormat], fmt.Sprintf("%v; scored as %s", err, DefaultFormat)
+	}
+	return scoring, ""
+}
+
+// resolveRoundFormat returns the scoring rules for a round's format. A
+// format that cannot be resolved scores by DefaultFormat; the leaderboard
+// reports it.
+func resolveRoundFormat(db *store.Store, round models.TournamentRound) (ScoringFormat, error) {
+	formats, err := db.GetAllFormats()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range formats {
+		if f.ID == round.FormatID {
+			scoring, _ := resolveFormatOrDefault(f)
+			return scoring, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown format")
+}
+
+// holeComplete reports whether enough entries exist for a hole to count.
+// Per-player formats wait for every player on the team to post.
+func holeComplete(f ScoringFormat, entries int, teamSize int) bool {
+	if f.EntryMode() == EntryPerTeam {
+		return entries > 0
+	}
+	return entries >= teamSize
+}
+
+func init() {
+	// Default allowances follow the WHS Appendix C recommendations.
+	RegisterFormat(strokeFormat{code: FormatScramble, entryMode: EntryPerTeam, scoresToCount: 1, allowance: 1.0, aggregation: AggregateTeamBall, teamWeights: ScrambleWeights})
+	RegisterFormat(strokeFormat{code: FormatAlternateShot, entryMode: EntryPerTeam, scoresToCount: 1, allowance: 1.0, aggregation: AggregateTeamBall, teamWeights: FoursomesWeights})
+	RegisterFormat(strokeFormat{code: FormatShamble, entryMode: EntryPerPlayer, scoresToCount: 1, allowance: 0.85, aggregation: AggregateBestN})
+	RegisterFormat(strokeFormat{code: FormatBestBall, entryMode: EntryPerPlayer, scoresToCount: 1, allowance: 0.85, aggregation: AggregateBestN})
+	RegisterFormat(strokeFormat{code: FormatTwoBestBalls, entryMode: EntryPerPlayer, scoresToCount: 2, allowance: 0.85, aggregation: AggregateBestN})
+	RegisterFormat(strokeFormat{code: FormatMatchPlay, entryMode: EntryPerPlayer, scoresToCount: 1, allowance: 0.90, aggregation: AggregateBestN})
+	RegisterFormat(strokeFormat{code: FormatStrokePlay, entryMode: EntryPerPlayer, scoresToCount: 0, allowance: 0.95, aggregation: AggregateAll})
+	RegisterFormat(strokeFormat{code: FormatCombined, entryMode: EntryPerPlayer, scoresToCount: 0, allowance: 1.0, aggregation: AggregateAll})
+
+	RegisterFormat(stablefordFormat{code: FormatStableford, scoresToCount: 1, allowance: 0.95, points: StablefordPoints})
+	RegisterFormat(stablefordFormat{code: FormatStablefordGross, gross: true, scoresToCount: 1, allowance: 1.0, points: StablefordPoints})
+	RegisterFormat(stablefordFormat{code: FormatModifiedStableford, scoresToCount: 1, allowance: 0.95, points: ModifiedStablefordPoints})
+}
@@ -0,0 +1,235 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// StandardSlope is the slope rating of a course of standard difficulty
+const StandardSlope = 113
+
+// TeeRating is the difficulty of the tee set a player plays from
+type TeeRating struct {
+	Name         string
+	Slope        int
+	CourseRating float64
+	Par          int
+}
+
+// PlayerHandicap is a player's handicap for a single round
+type PlayerHandicap struct {
+	PlayerID        int     `json:"playerId"`
+	Name            string  `json:"name"`
+	TeamID          int     `json:"teamId"`
+	HandicapIndex   float64 `json:"handicapIndex"`
+	Tee             string  `json:"tee,omitempty"`
+	Slope           int     `json:"slope"`
+	CourseRating    float64 `json:"courseRating"`
+	Par             int     `json:"par"`
+	CourseHandicap  int     `json:"courseHandicap"`
+	Allowance       float64 `json:"allowance"`
+	PlayingHandicap int     `json:"playingHandicap"`
+}
+
+// CourseHandicap converts a handicap index to strokes for a tee set:
+// Index × (Slope / 113) + (Course Rating − Par), rounded.
+func CourseHandicap(index float64, tee TeeRating) int {
+	slope := tee.Slope
+	if slope == 0 {
+		slope = StandardSlope
+	}
+	return int(math.Round(index*float64(slope)/StandardSlope + (tee.CourseRating - float64(tee.Par))))
+}
+
+// PlayingHandicap applies a format's handicap allowance to a course handicap
+func PlayingHandicap(courseHandicap int, allowance float64) int {
+	if allowance == 0 {
+		allowance = 1.0
+	}
+	return int(math.Round(float64(courseHandicap) * allowance))
+}
+
+// roundAllowance is the handicap allowance in play for a round
+func roundAllowance(round models.TournamentRound, scoring ScoringFormat) float64 {
+	if round.AwardedHandicap > 0 {
+		return round.AwardedHandicap
+	}
+	return scoring.DefaultAllowance()
+}
+
+// teeRating resolves the tee a player plays on a course. Players keep their
+// tee across rounds, so on another course the tee with the same name is used.
+// Unrated tees play to the par of the course with a standard slope.
+func teeRating(player models.Player, tees []models.CourseTee, coursePar int) TeeRating {
+	rating := TeeRating{Name: player.TeeName, Slope: StandardSlope, CourseRating: float64(coursePar), Par: coursePar}
+
+	var tee *models.CourseTee
+	for i := range tees {
+		if tees[i].ID == player.Tee {
+			tee = &tees[i]
+			break
+		}
+	}
+	if tee == nil {
+		for i := range tees {
+			if tees[i].Name == player.TeeName {
+				tee = &tees[i]
+				break
+			}
+		}
+	}
+	if tee == nil {
+		return rating
+	}
+
+	rating.Name = tee.Name
+	if tee.Slope > 0 {
+		rating.Slope = tee.Slope
+	}
+	if tee.Par != nil {
+		rating.Par = *tee.Par
+	}
+	rating.CourseRating = float64(rating.Par)
+	if tee.CourseRating != nil {
+		rating.CourseRating = *tee.CourseRating
+	}
+	return rating
+}
+
+// HoleCount is the number of distinct holes in a round
+func HoleCount(holes []models.HoleData) int {
+	seen := make(map[int]bool)
+	for _, h := range holes {
+		seen[h.Number] = true
+	}
+	return len(seen)
+}
+
+// roundIndex scales an 18-hole handicap index to the holes being played.
+// 9-hole rounds play off half the index, per WHS; other loops scale the same way.
+func roundIndex(index float64, holeCount int) float64 {
+	if holeCount <= 0 || holeCount >= RegulationHoles {
+		return index
+	}
+	return index * float64(holeCount) / RegulationHoles
+}
+
+// coursePar is the total par of a course's holes
+func coursePar(holes []models.HoleData) int {
+	seen := make(map[int]bool)
+	par := 0
+	for _, h := range holes {
+		if seen[h.Number] {
+			continue
+		}
+		seen[h.Number] = true
+		par += h.Par
+	}
+	return par
+}
+
+// RoundHandicaps computes the course and playing handicap of each player for a round.
+// Tee ratings are taken to describe the holes in the round, so a 9-hole loop
+// needs 9-hole course rating and par on its tees.
+func RoundHandicaps(players []models.Player, tees []models.CourseTee, course *models.Course, allowance float64) map[int]PlayerHandicap {
+	par := coursePar(course.Meta.Holes)
+	holeCount := HoleCount(course.Meta.Holes)
+
+	handicaps := make(map[int]PlayerHandicap)
+	for _, p := range players {
+		tee := teeRating(p, tees, par)
+		courseHandicap := CourseHandicap(roundIndex(p.Handicap, holeCount), tee)
+		handicaps[p.ID] = PlayerHandicap{
+			PlayerID:        p.ID,
+			Name:            p.Name,
+			TeamID:          p.TeamID,
+			HandicapIndex:   p.Handicap,
+			Tee:             tee.Name,
+			Slope:           tee.Slope,
+			CourseRating:    tee.CourseRating,
+			Par:             tee.Par,
+			CourseHandicap:  courseHandicap,
+			Allowance:       allowance,
+			PlayingHandicap: PlayingHandicap(courseHandicap, allowance),
+		}
+	}
+	return handicaps
+}
+
+// loadRoundHandicaps fetches the tees for a round's course and computes handicaps
+func loadRoundHandicaps(db *store.Store, round models.TournamentRound, scoring ScoringFormat, course *models.Course, players []models.Player) (map[int]PlayerHandicap, error) {
+	tees, err := db.GetCourseTees(course.ID)
+	if err != nil {
+		return nil, err
+	}
+	players, err = roundPlayers(db, round.ID, players)
+	if err != nil {
+		return nil, err
+	}
+	return RoundHandicaps(players, tees, course, roundAllowance(round, scoring)), nil
+}
+
+// roundPlayers returns the players with the handicaps they play a round off:
+// the one kept for the round if their handicap changed after it began, or
+// their current one
+func roundPlayers(db *store.Store, roundID int, players []models.Player) ([]models.Player, error) {
+	kept, err := db.GetRoundHandicaps(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if len(kept) == 0 {
+		return players, nil
+	}
+	result := make([]models.Player, len(players))
+	for i, p := range players {
+		if h, ok := kept[p.ID]; ok {
+			p.Handicap = h
+		}
+		result[i] = p
+	}
+	return result, nil
+}
+
+// CalculateRoundHandicaps returns the handicap of every player in a round
+func CalculateRoundHandicaps(ctx context.Context, db *store.Store, roundID int) ([]PlayerHandicap, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return []PlayerHandicap{}, nil
+	}
+
+	players, err := db.GetTournamentPlayers(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	handicaps, err := loadRoundHandicaps(db, *round, scoring, course, players)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []PlayerHandicap{}
+	for _, p := range players {
+		results = append(results, handicaps[p.ID])
+	}
+	return results, nil
+}
@@ -0,0 +1,303 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+const (
+	// ScoringRecordSize is the number of most recent scores an index is calculated from
+	ScoringRecordSize = 20
+	// MaxHandicapIndex is the highest index a player can hold
+	MaxHandicapIndex = 54.0
+	// SoftCap is how far an index can rise above the low handicap index before
+	// further increases are halved
+	SoftCap = 3.0
+	// HardCap is the most an index can rise above the low handicap index
+	HardCap = 5.0
+)
+
+// RecordEntry is a differential in a player's scoring record
+type RecordEntry struct {
+	models.ScoreDifferential
+	// Counting is set for the differentials the current index is calculated from
+	Counting bool `json:"counting"`
+}
+
+// HandicapHistory is a player's scoring record and the index calculated from it
+type HandicapHistory struct {
+	PlayerID         int           `json:"playerId"`
+	Name             string        `json:"name"`
+	Handicap         float64       `json:"handicap"` // Handicap the player currently plays off
+	HandicapIndex    *float64      `json:"handicapIndex"`
+	LowHandicapIndex *float64      `json:"lowHandicapIndex"`
+	Differentials    []RecordEntry `json:"differentials"`
+}
+
+// ScoreDifferential rates a round against the difficulty of the tee it was played from:
+// (113 / Slope) × (Adjusted Gross − Course Rating), to one decimal.
+func ScoreDifferential(adjustedGross int, courseRating float64, slope int) float64 {
+	if slope == 0 {
+		slope = StandardSlope
+	}
+	return roundTenth(StandardSlope / float64(slope) * (float64(adjustedGross) - courseRating))
+}
+
+// differentialsToCount returns how many of the lowest differentials make up an
+// index for a record of n scores, and the adjustment applied to their average
+func differentialsToCount(n int) (int, float64) {
+	switch {
+	case n < 3:
+		return 0, 0
+	case n == 3:
+		return 1, -2.0
+	case n == 4:
+		return 1, -1.0
+	case n == 5:
+		return 1, 0
+	case n == 6:
+		return 2, -1.0
+	case n <= 8:
+		return 2, 0
+	case n <= 11:
+		return 3, 0
+	case n <= 14:
+		return 4, 0
+	case n <= 16:
+		return 5, 0
+	case n <= 18:
+		return 6, 0
+	case n == 19:
+		return 7, 0
+	}
+	return 8, 0
+}
+
+// CalculateHandicapIndex returns the index for a scoring record, most recent
+// last. Only the last 20 scores count. When low is set, increases above the
+// low handicap index are limited by the soft and hard caps.
+func CalculateHandicapIndex(differentials []float64, low *float64) (float64, bool) {
+	if len(differentials) > ScoringRecordSize {
+		differentials = differentials[len(differentials)-ScoringRecordSize:]
+	}
+
+	count, adjustment := differentialsToCount(len(differentials))
+	if count == 0 {
+		return 0, false
+	}
+
+	sorted := make([]float64, len(differentials))
+	copy(sorted, differentials)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, d := range sorted[:count] {
+		total += d
+	}
+	index := total/float64(count) + adjustment
+
+	if low != nil {
+		if increase := index - *low; increase > SoftCap {
+			index = *low + SoftCap + (increase-SoftCap)/2
+		}
+		index = math.Min(index, *low+HardCap)
+	}
+
+	return math.Min(roundTenth(index), MaxHandicapIndex), true
+}
+
+// RecalculateIndexes sets the index after each score in a record, oldest first.
+// Caps apply once the record is full, against the lowest index of the
+// preceding 365 days.
+func RecalculateIndexes(record []models.ScoreDifferential) {
+	for i := range record {
+		start := 0
+		if i+1 > ScoringRecordSize {
+			start = i + 1 - ScoringRecordSize
+		}
+		window := []float64{}
+		for _, d := range record[start : i+1] {
+			window = append(window, d.Differential)
+		}
+
+		var low *float64
+		if i+1 >= ScoringRecordSize {
+			low = lowHandicapIndex(record[:i], record[i].PlayedAt)
+		}
+
+		record[i].HandicapIndex = nil
+		if index, ok := CalculateHandicapIndex(window, low); ok {
+			record[i].HandicapIndex = &index
+		}
+	}
+}
+
+// lowHandicapIndex is the lowest index held in the year before a date
+func lowHandicapIndex(record []models.ScoreDifferential, playedAt string) *float64 {
+	until, err := time.Parse("2006-01-02", playedAt)
+	if err != nil {
+		return nil
+	}
+	from := until.AddDate(-1, 0, 0)
+
+	var low *float64
+	for _, d := range record {
+		at, err := time.Parse("2006-01-02", d.PlayedAt)
+		if err != nil || at.Before(from) || d.HandicapIndex == nil {
+			continue
+		}
+		if low == nil || *d.HandicapIndex < *low {
+			v := *d.HandicapIndex
+			low = &v
+		}
+	}
+	return low
+}
+
+// PostRoundDifferentials posts a differential for every player who completed
+// an 18-hole round, adjusting gross scores to net double bogey, and
+// recalculates their indexes. When updateHandicaps is set players take their
+// new index as their handicap; rounds already begun keep the handicap they
+// were played off.
+func PostRoundDifferentials(ctx context.Context, db *store.Store, roundID int, updateHandicaps bool) ([]models.ScoreDifferential, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil || HoleCount(course.Meta.Holes) != RegulationHoles {
+		return []models.ScoreDifferential{}, nil
+	}
+
+	players, err := db.GetTournamentPlayers(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	handicaps, err := loadRoundHandicaps(db, *round, scoring, course, players)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := db.GetRoundScores(roundID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	playerScores := make(map[int]map[int]int)
+	for _, s := range scores {
+		if s.PlayerID == nil {
+			continue
+		}
+		if _, ok := playerScores[*s.PlayerID]; !ok {
+			playerScores[*s.PlayerID] = make(map[int]int)
+		}
+		playerScores[*s.PlayerID][s.CourseHoleID] = s.Strokes
+	}
+
+	// Handicap scores are always adjusted to net double bogey
+	netDoubleBogey := &models.MaxScore{Rule: models.MaxScoreNetDoubleBogey}
+
+	diffs := []models.ScoreDifferential{}
+	for _, p := range players {
+		h := handicaps[p.ID]
+		adjusted := AdjustRound(course.Meta.Holes, playerScores[p.ID], h.CourseHandicap, netDoubleBogey)
+		if adjusted.HolesPlayed != RegulationHoles {
+			continue
+		}
+		diffs = append(diffs, models.ScoreDifferential{
+			PlayerID:          p.ID,
+			TournamentRoundID: roundID,
+			AdjustedGross:     adjusted.AdjustedGross,
+			CourseRating:      h.CourseRating,
+			Slope:             h.Slope,
+			Differential:      ScoreDifferential(adjusted.AdjustedGross, h.CourseRating, h.Slope),
+		})
+	}
+
+	// The whole round posts at once; a player's new index becomes their
+	// handicap for the rounds they have not begun
+	return db.PostScoreDifferentialsTx(roundID, diffs, func(record []models.ScoreDifferential) *float64 {
+		RecalculateIndexes(record)
+		if updateHandicaps && len(record) > 0 {
+			return record[len(record)-1].HandicapIndex
+		}
+		return nil
+	})
+}
+
+// GetHandicapHistory returns a player's scoring record and current index
+func GetHandicapHistory(ctx context.Context, db *store.Store, playerID int) (*HandicapHistory, error) {
+	player, err := db.GetPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, nil
+	}
+
+	record, err := db.GetPlayerDifferentials(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &HandicapHistory{
+		PlayerID:      player.ID,
+		Name:          player.Name,
+		Handicap:      player.Handicap,
+		Differentials: []RecordEntry{},
+	}
+	if len(record) == 0 {
+		return history, nil
+	}
+
+	latest := record[len(record)-1]
+	history.HandicapIndex = latest.HandicapIndex
+	history.LowHandicapIndex = lowHandicapIndex(record, latest.PlayedAt)
+
+	// Mark the lowest differentials of the last 20 as counting
+	start := 0
+	if len(record) > ScoringRecordSize {
+		start = len(record) - ScoringRecordSize
+	}
+	recent := make([]int, 0, len(record)-start)
+	for i := start; i < len(record); i++ {
+		recent = append(recent, i)
+	}
+	sort.SliceStable(recent, func(a, b int) bool {
+		return record[recent[a]].Differential < record[recent[b]].Differential
+	})
+	count, _ := differentialsToCount(len(recent))
+	counting := make(map[int]bool)
+	for _, i := range recent[:count] {
+		counting[i] = true
+	}
+
+	for i, d := range record {
+		history.Differentials = append(history.Differentials, RecordEntry{
+			ScoreDifferential: d,
+			Counting:          counting[i],
+		})
+	}
+	return history, nil
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
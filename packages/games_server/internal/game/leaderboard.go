@@ -3,8 +3,6 @@ package game
 import (
 	"context"
 	"fmt"
-	"sort"
-	"strings"
 	"time"
 
 	"github.com/patrick-salvatore/games-server/internal/infra"
@@ -13,36 +11,120 @@ import (
 )
 
 type LeaderboardEntry struct {
-	Position int    `json:"position"`
-	TeamID   int    `json:"teamId"`
-	TeamName string `json:"name"`
-	Score    int    `json:"score"` // Relative to par
-	Thru     int    `json:"thru"`
+	Position int `json:"position"`
+	// PositionLabel is the position as displayed, e.g. "T3" for a shared position
+	PositionLabel string `json:"positionLabel"`
+	TeamID        int    `json:"teamId"`
+	TeamName      string `json:"name"`
+	Score         int    `json:"score"` // Relative to par, or points for points formats
+	Thru          int    `json:"thru"`
+	// Points from completed match play matches (1 win, 0.5 halve)
+	MatchPoints float64 `json:"matchPoints,omitempty"`
+	// Rounds breaks the score down by stroke play round, in round order
+	Rounds []RoundScore `json:"rounds"`
+	// MissedCut is set when the team did not make the cut; it ranks below
+	// every team that did
+	MissedCut bool `json:"missedCut,omitempty"`
+	// Projection from simulating the holes left to play, while any are
+	WinProbability  *float64 `json:"winProbability,omitempty"`  // 0 to 1
+	ProjectedFinish *float64 `json:"projectedFinish,omitempty"` // Average finishing position
+}
+
+// RoundScore is a team's or group's score in a single round
+type RoundScore struct {
+	RoundID     int    `json:"roundId"`
+	RoundNumber int    `json:"roundNumber"`
+	Name        string `json:"name"`
+	Score       int    `json:"score"` // Relative to par, or points for points formats
+	Thru        int    `json:"thru"`
 }
 
 type GroupLeaderboardEntry struct {
-	Position  int    `json:"position"`
-	GroupID   int    `json:"groupId"`
-	GroupName string `json:"name"`
-	Score     int    `json:"score"`
-	Thru      int    `json:"thru"`
+	Position      int    `json:"position"`
+	PositionLabel string `json:"positionLabel"`
+	GroupID       int    `json:"groupId"`
+	GroupName     string `json:"name"`
+	Score         int    `json:"score"`
+	Thru          int    `json:"thru"`
+	// Points from completed match play matches (1 win, 0.5 halve)
+	MatchPoints float64      `json:"matchPoints,omitempty"`
+	Rounds      []RoundScore `json:"rounds"`
 }
 
 type LeaderboardResponse struct {
-	TournamentID int                     `json:"tournamentId"`
-	Format       string                  `json:"format"`
-	Leaderboard  []LeaderboardEntry      `json:"leaderboard"` // Legacy/Teams
-	Teams        []LeaderboardEntry      `json:"teams"`       // Explicit Teams
-	Groups       []GroupLeaderboardEntry `json:"groups"`      // Groups
+	TournamentID int `json:"tournamentId"`
+	// RoundID is set when the leaderboard covers a single round
+	RoundID    int    `json:"roundId,omitempty"`
+	Format     string `json:"format"`
+	FormatCode string `json:"formatCode"`
+	// SortDirection is "asc" for strokes and "desc" for points formats
+	SortDirection SortDirection           `json:"sortDirection"`
+	Leaderboard   []LeaderboardEntry      `json:"leaderboard"` // Legacy/Teams
+	Teams         []LeaderboardEntry      `json:"teams"`       // Explicit Teams
+	Groups        []GroupLeaderboardEntry `json:"groups"`      // Groups
+	// Individual standings on each player's own ball
+	PlayersGross []PlayerLeaderboardEntry `json:"playersGross"`
+	PlayersNet   []PlayerLeaderboardEntry `json:"playersNet"`
+	Matches      []MatchStatus            `json:"matches,omitempty"`
+	// Cut is the tournament's cut line, when it has one
+	Cut *CutLine `json:"cut,omitempty"`
+	// Simulations behind the teams' projections, 0 when not projected
+	Simulations int `json:"simulations,omitempty"`
+	// FormatWarnings lists the rounds' formats that could not be resolved
+	// and were scored as DefaultFormat
+	FormatWarnings []string `json:"formatWarnings,omitempty"`
+}
+
+// scoreSource supplies the scores of a round and the team hole results
+// scored from them
+type scoreSource interface {
+	RoundScores(roundID int) ([]models.Score, error)
+	RoundResults(roundID int) ([]models.TeamHoleResult, error)
+}
+
+// liveScores reads the scores as they stand
+type liveScores struct {
+	db *store.Store
+}
+
+func (l liveScores) RoundScores(roundID int) ([]models.Score, error) {
+	return l.db.GetRoundScores(roundID, nil, nil)
+}
+
+func (l liveScores) RoundResults(roundID int) ([]models.TeamHoleResult, error) {
+	return l.db.GetRoundResults(roundID)
 }
 
 // TeamRoundStats is exported to allow caching (json marshalling)
 type TeamRoundStats struct {
 	TotalScore  int `json:"totalScore"`
 	HolesPlayed int `json:"holesPlayed"`
+	// Holes maps hole number to the team's score on it, for countback
+	Holes      map[int]int `json:"holes,omitempty"`
+	RoundHoles int         `json:"roundHoles"` // Holes in the round
 }
 
+// CalculateLeaderboard ranks teams and groups over every round of a tournament
 func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.CacheManager, tournamentID int) (*LeaderboardResponse, error) {
+	return calculateLeaderboard(ctx, db, cache, liveScores{db}, tournamentID, 0)
+}
+
+// CalculateRoundLeaderboard ranks teams and groups on a single round of a tournament.
+// Ties are broken by countback over that round.
+func CalculateRoundLeaderboard(ctx context.Context, db *store.Store, cache *infra.CacheManager, tournamentID int, roundID int) (*LeaderboardResponse, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil || round.TournamentID != tournamentID {
+		return nil, fmt.Errorf("round not found")
+	}
+	return calculateLeaderboard(ctx, db, cache, liveScores{db}, tournamentID, roundID)
+}
+
+// calculateLeaderboard builds the leaderboard over all rounds, or over one
+// round when roundID is set, from the scores src supplies
+func calculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.CacheManager, src scoreSource, tournamentID int, roundID int) (*LeaderboardResponse, error) {
 	// 1. Fetch Tournament
 	t, err := db.GetTournament(tournamentID)
 	if err != nil {
@@ -52,21 +134,30 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 		return nil, fmt.Errorf("tournament not found")
 	}
 
-	// 2. Fetch All Formats (to map ID -> Name)
+	// 2. Fetch All Formats (to map ID -> Format)
 	formats, err := db.GetAllFormats()
 	if err != nil {
 		return nil, err
 	}
-	formatMap := make(map[int]string)
+	formatMap := make(map[int]models.TournamentFormat)
 	for _, f := range formats {
-		formatMap[f.ID] = f.Name
+		formatMap[f.ID] = f
 	}
 
 	// 3. Fetch Tournament Rounds
-	rounds, err := db.GetTournamentRounds(tournamentID)
+	allRounds, err := db.GetTournamentRounds(tournamentID)
 	if err != nil {
 		return nil, err
 	}
+	rounds := allRounds
+	if roundID != 0 {
+		rounds = []models.TournamentRound{}
+		for _, r := range allRounds {
+			if r.ID == roundID {
+				rounds = append(rounds, r)
+			}
+		}
+	}
 
 	// 4. Fetch Teams & Players
 	teams, err := db.GetTeamsByTournament(tournamentID)
@@ -98,19 +189,18 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 		teamToGroup[int(m.TeamID)] = int(m.GroupID)
 	}
 
-	players, err := db.GetAvailablePlayers(tournamentID)
+	players, err := db.GetTournamentPlayers(tournamentID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Map PlayerID -> Handicap
-	playerHandicap := make(map[int]float64)
-	// Map TeamID -> Player Count (for completion check)
-	teamPlayerCount := make(map[int]int)
-
-	for _, p := range players {
-		playerHandicap[p.PlayerID] = float64(p.Handicap)
-		teamPlayerCount[p.TeamID]++
+	missedCuts, err := db.GetMissedCuts(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	missedCut := make(map[int]bool)
+	for _, m := range missedCuts {
+		missedCut[m.TeamID] = true
 	}
 
 	// 5. Initialize Stats Accumulator
@@ -123,124 +213,163 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 		}
 	}
 
-	var activeFormatName string
+	var activeFormat models.TournamentFormat
+	direction := SortAscending
+
+	// Each team's last round with scores decides countback ties
+	finalRound := make(map[int]*TeamRoundStats)
+
+	// Per-round breakdown of each team's score
+	roundScores := make(map[int][]RoundScore)
+
+	// Individual standings, accumulated over the stroke play rounds
+	playerStats := make(map[int]*PlayerLeaderboardEntry)
+	for _, p := range players {
+		playerStats[p.ID] = &PlayerLeaderboardEntry{
+			PlayerID: p.ID,
+			Name:     p.Name,
+			TeamID:   p.TeamID,
+			TeamName: teamMap[p.TeamID].Name,
+			Handicap: p.Handicap,
+			Rounds:   []PlayerRoundScore{},
+		}
+	}
+
+	// Formats that fell back to the default scoring
+	var formatWarnings []string
+	warned := make(map[int]bool)
+
+	// Match play rounds are scored by holes won, not strokes
+	matches := []MatchStatus{}
+	matchPoints := make(map[int]float64)
 
 	// 6. Iterate Through Rounds and Accumulate Scores
 	for _, round := range rounds {
-		// Determine Format Name for this round
-		formatName, ok := formatMap[round.FormatID]
+		// Resolve the scoring rules for this round's format
+		format, ok := formatMap[round.FormatID]
 		if !ok {
 			return nil, fmt.Errorf("unknown format")
 		}
+		scoring, warning := resolveFormatOrDefault(format)
+		if warning != "" && !warned[format.ID] {
+			warned[format.ID] = true
+			formatWarnings = append(formatWarnings, warning)
+		}
 
-		// Keep track of the active round's format for the response
-		if round.Status == "active" {
-			activeFormatName = formatName
+		// Keep track of the active round's format for the response.
+		// Ranking follows the active round, or the latest round otherwise.
+		// A single round leaderboard follows that round.
+		if round.Status == "active" || roundID != 0 {
+			activeFormat = format
+			direction = scoring.SortDirection()
+		} else if activeFormat.ID == 0 {
+			direction = scoring.SortDirection()
+		}
+
+		if round.IsMatchPlay {
+			roundMatches, err := calculateRoundMatches(ctx, db, src, round.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range roundMatches {
+				a, b := m.Points()
+				matchPoints[m.SideA.TeamID] += a
+				matchPoints[m.SideB.TeamID] += b
+			}
+			matches = append(matches, roundMatches...)
+			continue
 		}
 
 		// --- Cache Check ---
 		var currentRoundStats map[int]*TeamRoundStats
+		var currentPlayerStats map[int]*PlayerRoundStats
 		cacheKey := fmt.Sprintf("round_stats:%d", round.ID)
+		playerCacheKey := fmt.Sprintf("round_player_stats:%d", round.ID)
 
 		if round.Status == "completed" && cache != nil {
 			var cached map[int]*TeamRoundStats
-			if cache.Get(cacheKey, &cached) {
+			var cachedPlayers map[int]*PlayerRoundStats
+			if cache.Get(cacheKey, &cached) && cache.Get(playerCacheKey, &cachedPlayers) {
 				currentRoundStats = cached
+				currentPlayerStats = cachedPlayers
 			}
 		}
 
 		// If not cached, calculate it
 		if currentRoundStats == nil {
-			currentRoundStats = make(map[int]*TeamRoundStats)
-
-			// Fetch Course for this round
-			course, err := db.GetCourseByTournamentRoundID(round.ID)
+			rs, err := loadRoundScorer(db, round, scoring, t.MaxScore, players)
 			if err != nil {
 				return nil, err
 			}
-			if course == nil {
-				// Skip rounds without a course? Or error?
+			if rs == nil {
+				// Skip rounds without a course
 				continue
 			}
 
-			holeMap := make(map[int]models.HoleData)
-			for _, h := range course.Meta.Holes {
-				holeMap[h.ID] = h
-			}
-
-			// Fetch Scores for this round
-			scores, err := db.GetRoundScores(round.ID, nil, nil)
+			// Team hole results are maintained as scores are submitted
+			results, err := src.RoundResults(round.ID)
 			if err != nil {
 				return nil, err
 			}
 
-			// Group Scores by Team -> Hole
-			teamHoleInputs := make(map[int]map[int][]ScoreInput)
-
-			for _, s := range scores {
-				// Only process if valid TeamID
-				var tID int
-				if s.TeamID != nil {
-					tID = *s.TeamID
-				} else {
-					// Scramble scores might have TeamID but no PlayerID.
-					// Best Ball must have TeamID (as per query logic in original code, it filtered by TeamID in loop).
-					// If s.TeamID is nil, we skip.
-					continue
-				}
-
-				hcp := 0.0
-				if s.PlayerID != nil {
-					if h, ok := playerHandicap[*s.PlayerID]; ok {
-						hcp = h
+			currentRoundStats = make(map[int]*TeamRoundStats)
+			for _, r := range results {
+				if _, ok := currentRoundStats[r.TeamID]; !ok {
+					currentRoundStats[r.TeamID] = &TeamRoundStats{
+						Holes:      make(map[int]int),
+						RoundHoles: rs.holeCount,
 					}
 				}
-
-				if _, ok := teamHoleInputs[tID]; !ok {
-					teamHoleInputs[tID] = make(map[int][]ScoreInput)
-				}
-				teamHoleInputs[tID][s.CourseHoleID] = append(teamHoleInputs[tID][s.CourseHoleID], ScoreInput{
-					Gross:    s.Strokes,
-					Handicap: hcp,
-				})
+				currentRoundStats[r.TeamID].TotalScore += r.Score
+				currentRoundStats[r.TeamID].HolesPlayed++
+				currentRoundStats[r.TeamID].Holes[r.HoleNumber] = r.Score
 			}
 
-			for tID, holeInputs := range teamHoleInputs {
-				if _, ok := currentRoundStats[tID]; !ok {
-					currentRoundStats[tID] = &TeamRoundStats{}
-				}
-
-				lowerFormat := strings.ToLower(formatName)
-				isTeamAgg := strings.Contains(lowerFormat, "best ball") || strings.Contains(lowerFormat, "combined")
-
-				for hID, inputs := range holeInputs {
-					hole, ok := holeMap[hID]
-					if !ok {
-						continue
-					}
-
-					if isTeamAgg {
-						required := teamPlayerCount[tID]
-						if len(inputs) < required {
-							continue
-						}
-					}
-
-					// Calculate Hole Score
-					net := CalculateHoleScore(formatName, inputs, hole.Par, hole.Handicap, hole.AllowedHandicap)
-
-					currentRoundStats[tID].TotalScore += net
-					currentRoundStats[tID].HolesPlayed++
-				}
+			// Players are scored on their own ball from the raw scores
+			scores, err := src.RoundScores(round.ID)
+			if err != nil {
+				return nil, err
 			}
+			currentPlayerStats = scorePlayerRound(rs.course.Meta.Holes, rs.handicaps, scores, t.MaxScore)
 
 			// --- Save to Cache if Completed ---
 			if round.Status == "completed" && cache != nil {
 				// Cache for a long time (e.g., 24 hours)
 				cache.Set(cacheKey, currentRoundStats, 24*time.Hour)
+				cache.Set(playerCacheKey, currentPlayerStats, 24*time.Hour)
 			}
 		}
 
+		// --- Record the Round Breakdown ---
+		for tID := range teamMap {
+			rs := RoundScore{RoundID: round.ID, RoundNumber: round.RoundNumber, Name: round.Name}
+			if s, ok := currentRoundStats[tID]; ok {
+				rs.Score = s.TotalScore
+				rs.Thru = s.HolesPlayed
+			}
+			roundScores[tID] = append(roundScores[tID], rs)
+		}
+
+		for pID, ps := range currentPlayerStats {
+			entry, ok := playerStats[pID]
+			if !ok || ps.HolesPlayed == 0 {
+				continue
+			}
+			entry.Gross += ps.Gross
+			entry.Net += ps.Net
+			entry.StrokesReceived += ps.StrokesReceived
+			entry.Thru += ps.HolesPlayed
+			entry.Rounds = append(entry.Rounds, PlayerRoundScore{
+				RoundID:         round.ID,
+				RoundNumber:     round.RoundNumber,
+				PlayingHandicap: ps.PlayingHandicap,
+				StrokesReceived: ps.StrokesReceived,
+				Gross:           ps.Gross,
+				Net:             ps.Net,
+				Thru:            ps.HolesPlayed,
+			})
+		}
+
 		// --- Merge Round Stats into Tournament Stats ---
 		for tID, rs := range currentRoundStats {
 			if _, exists := stats[tID]; !exists {
@@ -249,8 +378,24 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 			}
 			stats[tID].TotalScore += rs.TotalScore
 			stats[tID].HolesPlayed += rs.HolesPlayed
+			if rs.HolesPlayed > 0 {
+				finalRound[tID] = rs
+			}
+		}
+	}
+
+	// A playoff decides the tournament, so it only breaks ties over all rounds
+	playoffPlace := make(map[int]int)
+	if roundID == 0 {
+		playoffs, err := db.GetPlayoffResults(tournamentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range playoffs {
+			playoffPlace[p.TeamID] = p.Place
 		}
 	}
+	segments := countbackSegments(t.Tiebreak)
 
 	// 7. Flatten to List (Teams)
 	leaderboard := []LeaderboardEntry{}
@@ -260,27 +405,51 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 			teamName = t.Name
 		}
 		leaderboard = append(leaderboard, LeaderboardEntry{
-			TeamID:   tID,
-			TeamName: teamName,
-			Score:    stat.TotalScore,
-			Thru:     stat.HolesPlayed,
+			TeamID:      tID,
+			TeamName:    teamName,
+			Score:       stat.TotalScore,
+			Thru:        stat.HolesPlayed,
+			MatchPoints: matchPoints[tID],
+			Rounds:      teamRounds(roundScores[tID]),
+			MissedCut:   missedCut[tID],
 		})
 	}
 
-	// 8. Sort Leaderboard (Lowest Score First)
-	sort.Slice(leaderboard, func(i, j int) bool {
-		if leaderboard[i].Score != leaderboard[j].Score {
-			return leaderboard[i].Score < leaderboard[j].Score
+	// 8. Rank Leaderboard (Best Score First, per the format's direction),
+	// breaking ties by playoff and countback
+	ranked := make([]rankedEntry, len(leaderboard))
+	for i, e := range leaderboard {
+		ranked[i] = rankedEntry{
+			Score:     e.Score,
+			Thru:      e.Thru,
+			Name:      e.TeamName,
+			Playoff:   playoffPlace[e.TeamID],
+			Countback: countbackTotals(finalRound[e.TeamID], segments),
+			MissedCut: e.MissedCut,
 		}
-		if leaderboard[i].Thru != leaderboard[j].Thru {
-			return leaderboard[i].Thru > leaderboard[j].Thru
-		}
-		return leaderboard[i].TeamName < leaderboard[j].TeamName
-	})
+	}
+	order, positions, shared := rankPositions(ranked, direction)
 
 	// 9. Assign Positions
-	for i := range leaderboard {
-		leaderboard[i].Position = i + 1
+	sortedTeams := make([]LeaderboardEntry, 0, len(leaderboard))
+	for _, idx := range order {
+		e := leaderboard[idx]
+		e.Position = positions[idx]
+		e.PositionLabel = positionLabel(positions[idx], shared[idx])
+		sortedTeams = append(sortedTeams, e)
+	}
+	leaderboard = sortedTeams
+
+	// The cut line is projected until the cut round completes
+	var cut *CutLine
+	if roundID == 0 && t.Cut != nil {
+		applied := false
+		for _, r := range allRounds {
+			if r.RoundNumber == t.Cut.AfterRound && r.Status == "completed" {
+				applied = true
+			}
+		}
+		cut = projectCut(t.Cut, leaderboard, direction, applied)
 	}
 
 	// 10. Aggregate Groups
@@ -289,6 +458,8 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 		GroupName   string
 		TotalScore  int
 		HolesPlayed int
+		MatchPoints float64
+		Rounds      []RoundScore
 	}
 	groupStats := make(map[int]*GroupStats)
 
@@ -306,36 +477,81 @@ func CalculateLeaderboard(ctx context.Context, db *store.Store, cache *infra.Cac
 		}
 		groupStats[groupID].TotalScore += stat.TotalScore
 		groupStats[groupID].HolesPlayed += stat.HolesPlayed
+		groupStats[groupID].MatchPoints += matchPoints[tID]
+		groupStats[groupID].Rounds = addRoundScores(groupStats[groupID].Rounds, roundScores[tID])
 	}
 
 	groupLeaderboard := []GroupLeaderboardEntry{}
 	for _, gs := range groupStats {
 		groupLeaderboard = append(groupLeaderboard, GroupLeaderboardEntry{
-			GroupID:   gs.GroupID,
-			GroupName: gs.GroupName,
-			Score:     gs.TotalScore,
-			Thru:      gs.HolesPlayed,
+			GroupID:     gs.GroupID,
+			GroupName:   gs.GroupName,
+			Score:       gs.TotalScore,
+			Thru:        gs.HolesPlayed,
+			MatchPoints: gs.MatchPoints,
+			Rounds:      teamRounds(gs.Rounds),
 		})
 	}
 
-	// Sort Groups
-	sort.Slice(groupLeaderboard, func(i, j int) bool {
-		if groupLeaderboard[i].Score != groupLeaderboard[j].Score {
-			return groupLeaderboard[i].Score < groupLeaderboard[j].Score
-		}
-		return groupLeaderboard[i].GroupName < groupLeaderboard[j].GroupName
-	})
+	// Rank Groups; tied groups share a position
+	rankedGroups := make([]rankedEntry, len(groupLeaderboard))
+	for i, g := range groupLeaderboard {
+		rankedGroups[i] = rankedEntry{Score: g.Score, Thru: g.Thru, Name: g.GroupName}
+	}
+	order, positions, shared = rankPositions(rankedGroups, direction)
+
+	sortedGroups := make([]GroupLeaderboardEntry, 0, len(groupLeaderboard))
+	for _, idx := range order {
+		g := groupLeaderboard[idx]
+		g.Position = positions[idx]
+		g.PositionLabel = positionLabel(positions[idx], shared[idx])
+		sortedGroups = append(sortedGroups, g)
+	}
+	groupLeaderboard = sortedGroups
 
-	// Assign Positions
-	for i := range groupLeaderboard {
-		groupLeaderboard[i].Position = i + 1
+	// 11. Rank Players
+	individuals := make([]PlayerLeaderboardEntry, 0, len(playerStats))
+	for _, p := range playerStats {
+		individuals = append(individuals, *p)
 	}
 
 	return &LeaderboardResponse{
-		TournamentID: tournamentID,
-		Format:       activeFormatName,
-		Leaderboard:  leaderboard, // Keep backward compatibility
-		Teams:        leaderboard,
-		Groups:       groupLeaderboard,
+		TournamentID:   tournamentID,
+		RoundID:        roundID,
+		Format:         activeFormat.Name,
+		FormatCode:     activeFormat.Code,
+		SortDirection:  direction,
+		Leaderboard:    leaderboard, // Keep backward compatibility
+		Teams:          leaderboard,
+		Groups:         groupLeaderboard,
+		PlayersGross:   rankPlayers(individuals, false),
+		PlayersNet:     rankPlayers(individuals, true),
+		Matches:        matches,
+		Cut:            cut,
+		FormatWarnings: formatWarnings,
 	}, nil
 }
+
+// teamRounds returns a round breakdown, never nil so it encodes as a list
+func teamRounds(rounds []RoundScore) []RoundScore {
+	if rounds == nil {
+		return []RoundScore{}
+	}
+	return rounds
+}
+
+// addRoundScores adds a team's round breakdown into a group's, round by round
+func addRoundScores(total []RoundScore, rounds []RoundScore) []RoundScore {
+	if total == nil {
+		total = make([]RoundScore, len(rounds))
+		copy(total, rounds)
+		return total
+	}
+	for i := range total {
+		if i < len(rounds) {
+			total[i].Score += rounds[i].Score
+			total[i].Thru += rounds[i].Thru
+		}
+	}
+	return total
+}
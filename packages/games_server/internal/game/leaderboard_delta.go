@@ -0,0 +1,108 @@
+package game
+
+import "reflect"
+
+// PositionMove is a team moving on the leaderboard
+type PositionMove struct {
+	TeamID int `json:"teamId"`
+	From   int `json:"from"` // 0 when the team is new to the board
+	To     int `json:"to"`
+}
+
+// LeaderboardDelta is the difference between two computations of a
+// tournament's leaderboard. Rows are the full new row of every team, group or
+// player that changed; a Full delta carries every row.
+type LeaderboardDelta struct {
+	TournamentID  int                      `json:"tournamentId"`
+	Version       int64                    `json:"version"` // Increases with each delta pushed for the tournament
+	Full          bool                     `json:"full"`
+	SortDirection SortDirection            `json:"sortDirection"`
+	Teams         []LeaderboardEntry       `json:"teams"`
+	Groups        []GroupLeaderboardEntry  `json:"groups"`
+	PlayersGross  []PlayerLeaderboardEntry `json:"playersGross"`
+	PlayersNet    []PlayerLeaderboardEntry `json:"playersNet"`
+	Moves         []PositionMove           `json:"moves"`
+}
+
+// Empty reports whether nothing on the leaderboard changed
+func (d LeaderboardDelta) Empty() bool {
+	return !d.Full && len(d.Teams) == 0 && len(d.Groups) == 0 &&
+		len(d.PlayersGross) == 0 && len(d.PlayersNet) == 0
+}
+
+// FullLeaderboardDelta returns a delta carrying every row of a leaderboard
+func FullLeaderboardDelta(lb *LeaderboardResponse) LeaderboardDelta {
+	return LeaderboardDelta{
+		TournamentID:  lb.TournamentID,
+		Full:          true,
+		SortDirection: lb.SortDirection,
+		Teams:         lb.Teams,
+		Groups:        lb.Groups,
+		PlayersGross:  lb.PlayersGross,
+		PlayersNet:    lb.PlayersNet,
+		Moves:         []PositionMove{},
+	}
+}
+
+// DiffLeaderboard returns the rows of next that differ from prev, and the
+// teams whose position changed. A nil prev gives a full delta.
+func DiffLeaderboard(prev, next *LeaderboardResponse) LeaderboardDelta {
+	// A change of ranking order reorders every row
+	if prev == nil || prev.SortDirection != next.SortDirection {
+		return FullLeaderboardDelta(next)
+	}
+
+	delta := LeaderboardDelta{
+		TournamentID:  next.TournamentID,
+		SortDirection: next.SortDirection,
+		Teams:         []LeaderboardEntry{},
+		Groups:        []GroupLeaderboardEntry{},
+		PlayersGross:  []PlayerLeaderboardEntry{},
+		PlayersNet:    []PlayerLeaderboardEntry{},
+		Moves:         []PositionMove{},
+	}
+
+	teams := make(map[int]LeaderboardEntry)
+	for _, e := range prev.Teams {
+		teams[e.TeamID] = e
+	}
+	for _, e := range next.Teams {
+		old, ok := teams[e.TeamID]
+		if ok && reflect.DeepEqual(old, e) {
+			continue
+		}
+		delta.Teams = append(delta.Teams, e)
+		if old.Position != e.Position {
+			delta.Moves = append(delta.Moves, PositionMove{TeamID: e.TeamID, From: old.Position, To: e.Position})
+		}
+	}
+
+	groups := make(map[int]GroupLeaderboardEntry)
+	for _, g := range prev.Groups {
+		groups[g.GroupID] = g
+	}
+	for _, g := range next.Groups {
+		if old, ok := groups[g.GroupID]; !ok || !reflect.DeepEqual(old, g) {
+			delta.Groups = append(delta.Groups, g)
+		}
+	}
+
+	delta.PlayersGross = changedPlayers(prev.PlayersGross, next.PlayersGross)
+	delta.PlayersNet = changedPlayers(prev.PlayersNet, next.PlayersNet)
+
+	return delta
+}
+
+func changedPlayers(prev, next []PlayerLeaderboardEntry) []PlayerLeaderboardEntry {
+	old := make(map[int]PlayerLeaderboardEntry)
+	for _, p := range prev {
+		old[p.PlayerID] = p
+	}
+	changed := []PlayerLeaderboardEntry{}
+	for _, p := range next {
+		if o, ok := old[p.PlayerID]; !ok || !reflect.DeepEqual(o, p) {
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,326 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+const (
+	MatchSideA  = "A"
+	MatchSideB  = "B"
+	MatchHalved = "halved"
+)
+
+// MatchSide is one side of a match: a team, or a single player in singles.
+type MatchSide struct {
+	TeamID   int    `json:"teamId"`
+	PlayerID *int   `json:"playerId,omitempty"`
+	Name     string `json:"name"`
+}
+
+// MatchHole is the result of a single hole in a match.
+type MatchHole struct {
+	HoleNumber int    `json:"holeNumber"`
+	NetA       int    `json:"netA"`   // Relative to par, or points
+	NetB       int    `json:"netB"`   // Relative to par, or points
+	Result     string `json:"result"` // "A", "B" or "halved"
+	Lead       int    `json:"lead"`   // Running lead after the hole, positive = side A up
+}
+
+type MatchStatus struct {
+	RoundID        int         `json:"roundId"`
+	MatchNumber    int         `json:"matchNumber"`
+	SideA          MatchSide   `json:"sideA"`
+	SideB          MatchSide   `json:"sideB"`
+	Lead           int         `json:"lead"`             // Positive = side A up
+	Leader         string      `json:"leader,omitempty"` // "A" or "B", empty when all square
+	Thru           int         `json:"thru"`
+	HolesRemaining int         `json:"holesRemaining"`
+	Dormie         bool        `json:"dormie"`
+	Complete       bool        `json:"complete"`
+	Winner         string      `json:"winner,omitempty"` // "A", "B" or "halved" once complete
+	Status         string      `json:"status"`           // e.g. "2 UP thru 11", "4&3", "All Square thru 6"
+	Holes          []MatchHole `json:"holes"`
+}
+
+// Points returns the match points earned by each side (1 for a win, 0.5 for a halve).
+// Matches still in progress earn nothing.
+func (m MatchStatus) Points() (a float64, b float64) {
+	switch m.Winner {
+	case MatchSideA:
+		return 1, 0
+	case MatchSideB:
+		return 0, 1
+	case MatchHalved:
+		return 0.5, 0.5
+	}
+	return 0, 0
+}
+
+// ScoreMatch plays a match hole by hole. sideA and sideB map course hole IDs to
+// the entries posted by each side, sideSize is the number of entries a side needs
+// for a hole to count. maxScore caps each hole when set.
+func ScoreMatch(format ScoringFormat, holes []models.HoleData, sideA, sideB map[int][]ScoreInput, sideSizeA, sideSizeB int, maxScore *models.MaxScore) MatchStatus {
+	ordered := make([]models.HoleData, len(holes))
+	copy(ordered, holes)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Number < ordered[j].Number
+	})
+
+	holeCount := HoleCount(ordered)
+	status := MatchStatus{
+		HolesRemaining: len(ordered),
+		Holes:          []MatchHole{},
+	}
+
+	for _, hole := range ordered {
+		entriesA := sideA[hole.ID]
+		entriesB := sideB[hole.ID]
+		if !holeComplete(format, len(entriesA), sideSizeA) || !holeComplete(format, len(entriesB), sideSizeB) {
+			continue
+		}
+
+		netA := CalculateHoleScore(format, entriesA, NewHoleContext(hole, holeCount, maxScore))
+		netB := CalculateHoleScore(format, entriesB, NewHoleContext(hole, holeCount, maxScore))
+
+		result := MatchHalved
+		if format.SortDirection().Better(netA, netB) {
+			result = MatchSideA
+			status.Lead++
+		} else if format.SortDirection().Better(netB, netA) {
+			result = MatchSideB
+			status.Lead--
+		}
+
+		status.Thru++
+		status.HolesRemaining--
+		status.Holes = append(status.Holes, MatchHole{
+			HoleNumber: hole.Number,
+			NetA:       netA,
+			NetB:       netB,
+			Result:     result,
+			Lead:       status.Lead,
+		})
+
+		// Closed out: the trailing side can no longer catch up
+		if abs(status.Lead) > status.HolesRemaining {
+			break
+		}
+	}
+
+	lead := abs(status.Lead)
+	if status.Lead > 0 {
+		status.Leader = MatchSideA
+	} else if status.Lead < 0 {
+		status.Leader = MatchSideB
+	}
+
+	switch {
+	case lead > status.HolesRemaining:
+		status.Complete = true
+		status.Winner = status.Leader
+		if status.HolesRemaining > 0 {
+			status.Status = fmt.Sprintf("%d&%d", lead, status.HolesRemaining)
+		} else {
+			status.Status = fmt.Sprintf("%d UP", lead)
+		}
+	case status.HolesRemaining == 0 && len(ordered) > 0:
+		// All holes played and level
+		status.Complete = true
+		status.Winner = MatchHalved
+		status.Status = "Halved"
+	case lead == 0:
+		status.Status = fmt.Sprintf("All Square thru %d", status.Thru)
+	default:
+		status.Dormie = lead == status.HolesRemaining
+		status.Status = fmt.Sprintf("%d UP thru %d", lead, status.Thru)
+	}
+
+	return status
+}
+
+// PairTeams builds default pairings for a round without stored matches.
+// With exactly two groups (e.g. Red vs Blue) teams are paired across groups
+// in team order; otherwise consecutive teams play each other.
+func PairTeams(teams []models.Team, teamToGroup map[int]int) []models.RoundMatch {
+	sorted := make([]models.Team, len(teams))
+	copy(sorted, teams)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	byGroup := make(map[int][]models.Team)
+	groupIDs := []int{}
+	for _, t := range sorted {
+		gID, ok := teamToGroup[t.ID]
+		if !ok {
+			continue
+		}
+		if _, seen := byGroup[gID]; !seen {
+			groupIDs = append(groupIDs, gID)
+		}
+		byGroup[gID] = append(byGroup[gID], t)
+	}
+	sort.Ints(groupIDs)
+
+	matches := []models.RoundMatch{}
+	if len(groupIDs) == 2 {
+		a, b := byGroup[groupIDs[0]], byGroup[groupIDs[1]]
+		for i := 0; i < len(a) && i < len(b); i++ {
+			matches = append(matches, models.RoundMatch{
+				MatchNumber: i + 1,
+				SideATeamID: a[i].ID,
+				SideBTeamID: b[i].ID,
+			})
+		}
+		return matches
+	}
+
+	for i := 0; i+1 < len(sorted); i += 2 {
+		matches = append(matches, models.RoundMatch{
+			MatchNumber: len(matches) + 1,
+			SideATeamID: sorted[i].ID,
+			SideBTeamID: sorted[i+1].ID,
+		})
+	}
+	return matches
+}
+
+// CalculateRoundMatches returns the status of every match in a match play round
+func CalculateRoundMatches(ctx context.Context, db *store.Store, roundID int) ([]MatchStatus, error) {
+	return calculateRoundMatches(ctx, db, liveScores{db}, roundID)
+}
+
+func calculateRoundMatches(ctx context.Context, db *store.Store, src scoreSource, roundID int) ([]MatchStatus, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+
+	tournament, err := db.GetTournament(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == nil {
+		return nil, fmt.Errorf("tournament not found")
+	}
+
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return []MatchStatus{}, nil
+	}
+
+	teams, err := db.GetTeamsByTournament(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	teamNames := make(map[int]string)
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+	}
+
+	players, err := db.GetTournamentPlayers(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	handicaps, err := loadRoundHandicaps(db, *round, scoring, course, players)
+	if err != nil {
+		return nil, err
+	}
+	teamHandicap := teamHandicaps(scoring, handicaps)
+	playerNames := make(map[int]string)
+	teamPlayerCount := make(map[int]int)
+	for _, p := range players {
+		playerNames[p.ID] = p.Name
+		teamPlayerCount[p.TeamID]++
+	}
+
+	pairings, err := db.GetRoundMatches(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pairings) == 0 {
+		members, err := db.GetTournamentGroupMembers(round.TournamentID)
+		if err != nil {
+			return nil, err
+		}
+		teamToGroup := make(map[int]int)
+		for _, m := range members {
+			teamToGroup[int(m.TeamID)] = int(m.GroupID)
+		}
+		pairings = PairTeams(teams, teamToGroup)
+	}
+
+	scores, err := src.RoundScores(roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index entries by team and by player, keyed by course hole
+	teamEntries := make(map[int]map[int][]ScoreInput)
+	playerEntries := make(map[int]map[int][]ScoreInput)
+	for _, s := range scores {
+		input := ScoreInput{Gross: s.Strokes}
+		if s.PlayerID != nil {
+			input.Handicap = float64(handicaps[*s.PlayerID].CourseHandicap)
+			if _, ok := playerEntries[*s.PlayerID]; !ok {
+				playerEntries[*s.PlayerID] = make(map[int][]ScoreInput)
+			}
+			playerEntries[*s.PlayerID][s.CourseHoleID] = append(playerEntries[*s.PlayerID][s.CourseHoleID], input)
+		}
+		if s.TeamID != nil {
+			if _, ok := teamEntries[*s.TeamID]; !ok {
+				teamEntries[*s.TeamID] = make(map[int][]ScoreInput)
+			}
+			teamInput := input
+			if teamHandicap != nil {
+				teamInput.Handicap = float64(teamHandicap[*s.TeamID])
+			}
+			teamEntries[*s.TeamID][s.CourseHoleID] = append(teamEntries[*s.TeamID][s.CourseHoleID], teamInput)
+		}
+	}
+
+	side := func(teamID int, playerID *int) (MatchSide, map[int][]ScoreInput, int) {
+		if playerID != nil {
+			return MatchSide{TeamID: teamID, PlayerID: playerID, Name: playerNames[*playerID]}, playerEntries[*playerID], 1
+		}
+		return MatchSide{TeamID: teamID, Name: teamNames[teamID]}, teamEntries[teamID], teamPlayerCount[teamID]
+	}
+
+	results := []MatchStatus{}
+	for _, p := range pairings {
+		sideA, entriesA, sizeA := side(p.SideATeamID, p.SideAPlayerID)
+		sideB, entriesB, sizeB := side(p.SideBTeamID, p.SideBPlayerID)
+
+		status := ScoreMatch(scoring, course.Meta.Holes, entriesA, entriesB, sizeA, sizeB, tournament.MaxScore)
+		status.RoundID = roundID
+		status.MatchNumber = p.MatchNumber
+		status.SideA = sideA
+		status.SideB = sideB
+		results = append(results, status)
+	}
+
+	return results, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"github.com/patrick-salvatore/games-server/internal/models"
+)
+
+// PlayerLeaderboardEntry is a player's individual standing, whatever the
+// format the player's team is scored on
+type PlayerLeaderboardEntry struct {
+	Position      int    `json:"position"`
+	PositionLabel string `json:"positionLabel"`
+	PlayerID      int    `json:"playerId"`
+	Name          string `json:"name"`
+	TeamID        int    `json:"teamId"`
+	TeamName      string `json:"teamName"`
+	// Handicap is the player's handicap index
+	Handicap        float64            `json:"handicap"`
+	StrokesReceived int                `json:"strokesReceived"` // Over the holes played
+	Score           int                `json:"score"`           // Gross or net relative to par, per the board
+	Gross           int                `json:"gross"`           // Relative to par
+	Net             int                `json:"net"`             // Relative to par
+	Thru            int                `json:"thru"`
+	Rounds          []PlayerRoundScore `json:"rounds"`
+}
+
+// PlayerRoundScore is a player's individual score in a single round
+type PlayerRoundScore struct {
+	RoundID         int `json:"roundId"`
+	RoundNumber     int `json:"roundNumber"`
+	PlayingHandicap int `json:"playingHandicap"`
+	StrokesReceived int `json:"strokesReceived"`
+	Gross           int `json:"gross"`
+	Net             int `json:"net"`
+	Thru            int `json:"thru"`
+}
+
+// PlayerRoundStats is exported to allow caching (json marshalling)
+type PlayerRoundStats struct {
+	PlayingHandicap int `json:"playingHandicap"`
+	StrokesReceived int `json:"strokesReceived"`
+	Gross           int `json:"gross"`
+	Net             int `json:"net"`
+	HolesPlayed     int `json:"holesPlayed"`
+}
+
+// scorePlayerRound scores every player's own ball in a round. Gross scores
+// are capped at the tournament's maximum hole score and net scores use the
+// player's playing handicap for the round. Team-only entries are ignored.
+func scorePlayerRound(holes []models.HoleData, handicaps map[int]PlayerHandicap, scores []models.Score, maxScore *models.MaxScore) map[int]*PlayerRoundStats {
+	holeCount := HoleCount(holes)
+	holeMap := make(map[int]models.HoleData)
+	for _, h := range holes {
+		holeMap[h.ID] = h
+	}
+
+	// A player's latest entry on a hole is the one that counts
+	gross := make(map[int]map[int]int)
+	for _, s := range scores {
+		if s.PlayerID == nil {
+			continue
+		}
+		if _, ok := gross[*s.PlayerID]; !ok {
+			gross[*s.PlayerID] = make(map[int]int)
+		}
+		gross[*s.PlayerID][s.CourseHoleID] = s.Strokes
+	}
+
+	stats := make(map[int]*PlayerRoundStats)
+	for playerID, holeScores := range gross {
+		ph := handicaps[playerID].PlayingHandicap
+		rs := &PlayerRoundStats{PlayingHandicap: ph}
+		for holeID, strokes := range holeScores {
+			hole, ok := holeMap[holeID]
+			if !ok {
+				continue
+			}
+			ctx := NewHoleContext(hole, holeCount, maxScore)
+			received := StrokesReceived(ph, ctx.StrokeIndex, holeCount)
+			adjusted := ctx.Adjusted(strokes, received)
+
+			rs.Gross += adjusted - hole.Par
+			rs.Net += adjusted - received - hole.Par
+			rs.StrokesReceived += received
+			rs.HolesPlayed++
+		}
+		stats[playerID] = rs
+	}
+	return stats
+}
+
+// rankPlayers orders individual standings on gross or net score, lowest
+// first. Players level on score share a position.
+func rankPlayers(entries []PlayerLeaderboardEntry, net bool) []PlayerLeaderboardEntry {
+	ranked := make([]rankedEntry, len(entries))
+	for i, e := range entries {
+		score := e.Gross
+		if net {
+			score = e.Net
+		}
+		ranked[i] = rankedEntry{Score: score, Thru: e.Thru, Name: e.Name}
+	}
+	order, positions, shared := rankPositions(ranked, SortAscending)
+
+	board := make([]PlayerLeaderboardEntry, 0, len(entries))
+	for _, idx := range order {
+		e := entries[idx]
+		e.Score = ranked[idx].Score
+		e.Position = positions[idx]
+		e.PositionLabel = positionLabel(positions[idx], shared[idx])
+		board = append(board, e)
+	}
+	return board
+}
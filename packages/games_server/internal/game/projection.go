@@ -0,0 +1,169 @@
+package game
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// ProjectionRuns is the number of simulated finishes behind a projection
+const ProjectionRuns = 2000
+
+// minHoleSamples is the fewest team scores on a hole for its own
+// distribution to be used; thinner holes sample the round as a whole
+const minHoleSamples = 3
+
+// ProjectLeaderboard simulates the holes every team has left on the
+// leaderboard's stroke play rounds and sets each team's win probability and
+// projected finish. Each remaining hole is drawn from the field's team scores
+// on that hole, falling back to the round's, then the tournament's, scores
+// while too few teams have played it. Teams that missed the cut play no
+// further holes and cannot win. A leaderboard with nothing left to play is
+// left as it is.
+func ProjectLeaderboard(ctx context.Context, db *store.Store, lb *LeaderboardResponse) error {
+	if len(lb.Teams) == 0 {
+		return nil
+	}
+
+	field := []int{}
+	remaining := make([][][]int, len(lb.Teams)) // team -> remaining hole -> distribution
+	seed := int64(lb.TournamentID)
+
+	// Every team carries the same stroke play rounds
+	for _, rs := range lb.Teams[0].Rounds {
+		round, err := db.GetTournamentRound(rs.RoundID)
+		if err != nil {
+			return err
+		}
+		if round == nil {
+			continue
+		}
+		course, err := db.GetCourseByTournamentRoundID(round.ID)
+		if err != nil {
+			return err
+		}
+		if course == nil {
+			continue
+		}
+		results, err := db.GetRoundResults(round.ID)
+		if err != nil {
+			return err
+		}
+
+		byHole := make(map[int][]int)
+		all := []int{}
+		played := make(map[int]map[int]bool)
+		for _, r := range results {
+			byHole[r.HoleNumber] = append(byHole[r.HoleNumber], r.Score)
+			all = append(all, r.Score)
+			if played[r.TeamID] == nil {
+				played[r.TeamID] = make(map[int]bool)
+			}
+			played[r.TeamID][r.HoleNumber] = true
+		}
+		field = append(field, all...)
+		seed = seed*31 + int64(len(results))
+
+		// Holes left in a completed round are not going to be played
+		if round.Status == "completed" {
+			continue
+		}
+
+		numbers := []int{}
+		seen := make(map[int]bool)
+		for _, h := range course.Meta.Holes {
+			if !seen[h.Number] {
+				seen[h.Number] = true
+				numbers = append(numbers, h.Number)
+			}
+		}
+
+		for i, e := range lb.Teams {
+			if e.MissedCut {
+				continue
+			}
+			for _, number := range numbers {
+				if played[e.TeamID][number] {
+					continue
+				}
+				// nil distributions are resolved to the field once it is known
+				var dist []int
+				if len(byHole[number]) >= minHoleSamples {
+					dist = byHole[number]
+				} else if len(all) > 0 {
+					dist = all
+				}
+				remaining[i] = append(remaining[i], dist)
+			}
+		}
+	}
+
+	left := false
+	for i := range remaining {
+		for j := range remaining[i] {
+			if remaining[i][j] == nil {
+				remaining[i][j] = field
+			}
+			left = true
+		}
+	}
+	if !left || len(field) == 0 {
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	n := len(lb.Teams)
+	wins := make([]float64, n)
+	finish := make([]float64, n)
+	totals := make([]int, n)
+	order := make([]int, n)
+
+	for run := 0; run < ProjectionRuns; run++ {
+		for i, e := range lb.Teams {
+			totals[i] = e.Score
+			for _, dist := range remaining[i] {
+				totals[i] += dist[rng.Intn(len(dist))]
+			}
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool {
+			ta, tb := lb.Teams[order[a]], lb.Teams[order[b]]
+			if ta.MissedCut != tb.MissedCut {
+				return tb.MissedCut
+			}
+			return lb.SortDirection.Better(totals[order[a]], totals[order[b]])
+		})
+
+		// Tied teams share a finish; a shared win is split between them
+		winners := []int{}
+		position := 1
+		for k, idx := range order {
+			prev := -1
+			if k > 0 {
+				prev = order[k-1]
+			}
+			if prev >= 0 && (totals[idx] != totals[prev] || lb.Teams[idx].MissedCut != lb.Teams[prev].MissedCut) {
+				position = k + 1
+			}
+			finish[idx] += float64(position)
+			if position == 1 && !lb.Teams[idx].MissedCut {
+				winners = append(winners, idx)
+			}
+		}
+		for _, idx := range winners {
+			wins[idx] += 1 / float64(len(winners))
+		}
+	}
+
+	for i := range lb.Teams {
+		p := math.Round(wins[i]/ProjectionRuns*1000) / 1000
+		f := roundTenth(finish[i] / ProjectionRuns)
+		lb.Teams[i].WinProbability = &p
+		lb.Teams[i].ProjectedFinish = &f
+	}
+	lb.Simulations = ProjectionRuns
+	return nil
+}
@@ -0,0 +1,317 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// LeaderboardReplay is a leaderboard rebuilt from the changelog as it stood
+// at a changelog version
+type LeaderboardReplay struct {
+	Version     int64                `json:"version"`
+	Changes     int                  `json:"changes"` // Score changes replayed
+	Leaderboard *LeaderboardResponse `json:"leaderboard"`
+}
+
+// LeadTeam is a team sharing the lead
+type LeadTeam struct {
+	TeamID int    `json:"teamId"`
+	Name   string `json:"name"`
+	Thru   int    `json:"thru"`
+}
+
+// LeadChange is a score change that changed who leads
+type LeadChange struct {
+	Version  int64      `json:"version"`
+	At       *time.Time `json:"at,omitempty"`
+	Score    int        `json:"score"` // The leading score
+	Leaders  []LeadTeam `json:"leaders"`
+	Previous []LeadTeam `json:"previous"`
+}
+
+// LeadTimeline lists every change of leader of a tournament, or of a round.
+// Changes before the changelog's compaction horizon are not listed.
+type LeadTimeline struct {
+	TournamentID  int           `json:"tournamentId"`
+	RoundID       int           `json:"roundId,omitempty"`
+	Horizon       int64         `json:"horizon,omitempty"`
+	SortDirection SortDirection `json:"sortDirection"`
+	Changes       []LeadChange  `json:"changes"`
+}
+
+// replayScores supplies the scores as they stood at a point in the
+// changelog. Team hole results are scored from them with today's rules and
+// handicaps.
+type replayScores struct {
+	db     *store.Store
+	scores map[int]models.Score // by score ID
+}
+
+func newReplayScores(db *store.Store, changes []models.ScoreChange) *replayScores {
+	r := &replayScores{db: db, scores: make(map[int]models.Score)}
+	for _, c := range changes {
+		if c.Score != nil {
+			r.scores[c.ScoreID] = *c.Score
+		} else {
+			delete(r.scores, c.ScoreID)
+		}
+	}
+	return r
+}
+
+func (r *replayScores) RoundScores(roundID int) ([]models.Score, error) {
+	scores := []models.Score{}
+	for _, s := range r.scores {
+		if s.TournamentRoundID != nil && *s.TournamentRoundID == roundID {
+			scores = append(scores, s)
+		}
+	}
+	// Later entries win, as with the live scores
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].ID < scores[j].ID
+	})
+	return scores, nil
+}
+
+func (r *replayScores) RoundResults(roundID int) ([]models.TeamHoleResult, error) {
+	round, scoring, t, players, err := loadRoundContext(r.db, roundID)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := loadRoundScorer(r.db, *round, scoring, t.MaxScore, players)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	scores, err := r.RoundScores(roundID)
+	if err != nil {
+		return nil, err
+	}
+	return rs.results(roundID, scores), nil
+}
+
+// ReplayLeaderboard rebuilds a tournament's leaderboard, or a round's when
+// roundID is set, from the score changes logged up to a changelog version.
+// Round status, cuts and playoffs are taken as they stand now.
+func ReplayLeaderboard(ctx context.Context, db *store.Store, tournamentID int, roundID int, version int64) (*LeaderboardReplay, error) {
+	if roundID != 0 {
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			return nil, err
+		}
+		if round == nil || round.TournamentID != tournamentID {
+			return nil, fmt.Errorf("round not found")
+		}
+	}
+
+	changes, err := db.GetScoreChanges(tournamentID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replays never touch the completed round cache
+	lb, err := calculateLeaderboard(ctx, db, nil, newReplayScores(db, changes), tournamentID, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LeaderboardReplay{
+		Version:     version,
+		Changes:     len(changes),
+		Leaderboard: lb,
+	}, nil
+}
+
+// resultKey identifies a team's hole in a round
+type resultKey struct {
+	roundID int
+	teamID  int
+	holeID  int
+}
+
+// LeaderboardTimeline replays the changelog of a tournament, or of one round
+// when roundID is set, and lists each change that changed the leaders. The
+// lead is on total score in the leaderboard's direction; tiebreaks are not
+// applied, so tied teams share it.
+func LeaderboardTimeline(ctx context.Context, db *store.Store, tournamentID int, roundID int) (*LeadTimeline, error) {
+	var lb *LeaderboardResponse
+	var err error
+	if roundID != 0 {
+		lb, err = CalculateRoundLeaderboard(ctx, db, nil, tournamentID, roundID)
+	} else {
+		lb, err = CalculateLeaderboard(ctx, db, nil, tournamentID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &LeadTimeline{
+		TournamentID:  tournamentID,
+		RoundID:       roundID,
+		SortDirection: lb.SortDirection,
+		Changes:       []LeadChange{},
+	}
+
+	t, err := db.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	rounds, err := db.GetTournamentRounds(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	players, err := db.GetTournamentPlayers(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := db.GetTeamsByTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string)
+	for _, tm := range teams {
+		names[tm.ID] = tm.Name
+	}
+
+	// Stroke play rounds in scope, with the rules to score them
+	scorers := make(map[int]*roundScorer)
+	for _, round := range rounds {
+		if round.IsMatchPlay || (roundID != 0 && round.ID != roundID) {
+			continue
+		}
+		scoring, err := resolveRoundFormat(db, round)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := loadRoundScorer(db, round, scoring, t.MaxScore, players)
+		if err != nil {
+			return nil, err
+		}
+		if rs != nil {
+			scorers[round.ID] = rs
+		}
+	}
+
+	changes, err := db.GetScoreChanges(tournamentID, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	timeline.Horizon, err = db.GetChangelogHorizon(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[int]models.Score)               // by score ID
+	entries := make(map[resultKey]map[int]models.Score) // team hole -> score ID -> score
+	results := make(map[resultKey]int)
+	totals := make(map[int]int)
+	thru := make(map[int]int)
+	previous := []LeadTeam{}
+
+	keyOf := func(s models.Score) (resultKey, bool) {
+		if s.TournamentRoundID == nil || s.TeamID == nil || scorers[*s.TournamentRoundID] == nil {
+			return resultKey{}, false
+		}
+		return resultKey{*s.TournamentRoundID, *s.TeamID, s.CourseHoleID}, true
+	}
+
+	for _, c := range changes {
+		touched := []resultKey{}
+		if old, ok := current[c.ScoreID]; ok {
+			if key, ok := keyOf(old); ok {
+				delete(entries[key], c.ScoreID)
+				touched = append(touched, key)
+			}
+			delete(current, c.ScoreID)
+		}
+		if c.Score != nil {
+			current[c.ScoreID] = *c.Score
+			if key, ok := keyOf(*c.Score); ok {
+				if entries[key] == nil {
+					entries[key] = make(map[int]models.Score)
+				}
+				entries[key][c.ScoreID] = *c.Score
+				touched = append(touched, key)
+			}
+		}
+		if len(touched) == 0 {
+			continue
+		}
+
+		// Rescore the team holes the change touched
+		for _, key := range touched {
+			if score, ok := results[key]; ok {
+				totals[key.teamID] -= score
+				thru[key.teamID]--
+				delete(results, key)
+			}
+			ids := make([]int, 0, len(entries[key]))
+			for id := range entries[key] {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+			holeEntries := make([]models.Score, 0, len(ids))
+			for _, id := range ids {
+				holeEntries = append(holeEntries, entries[key][id])
+			}
+			if _, score, ok := scorers[key.roundID].scoreHole(key.teamID, key.holeID, holeEntries); ok {
+				results[key] = score
+				totals[key.teamID] += score
+				thru[key.teamID]++
+			}
+		}
+
+		// Who leads now
+		leaders := []LeadTeam{}
+		best := 0
+		for teamID, n := range thru {
+			if n == 0 {
+				continue
+			}
+			switch {
+			case len(leaders) == 0 || lb.SortDirection.Better(totals[teamID], best):
+				best = totals[teamID]
+				leaders = []LeadTeam{{TeamID: teamID, Name: names[teamID], Thru: n}}
+			case totals[teamID] == best:
+				leaders = append(leaders, LeadTeam{TeamID: teamID, Name: names[teamID], Thru: n})
+			}
+		}
+		sort.Slice(leaders, func(i, j int) bool {
+			return leaders[i].TeamID < leaders[j].TeamID
+		})
+
+		// Compacted history only rebuilds the leaders at the horizon; thru
+		// moves on while the lead stands
+		if c.Version <= timeline.Horizon || sameLeaders(leaders, previous) {
+			previous = leaders
+			continue
+		}
+		timeline.Changes = append(timeline.Changes, LeadChange{
+			Version:  c.Version,
+			At:       c.At,
+			Score:    best,
+			Leaders:  leaders,
+			Previous: previous,
+		})
+		previous = leaders
+	}
+
+	return timeline, nil
+}
+
+func sameLeaders(a, b []LeadTeam) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].TeamID != b[i].TeamID {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// roundScorer holds the rules and handicaps a round's holes are scored with
+type roundScorer struct {
+	scoring      ScoringFormat
+	course       *models.Course
+	holes        map[int]models.HoleData // by course hole ID
+	holeCount    int
+	maxScore     *models.MaxScore
+	handicaps    map[int]PlayerHandicap
+	teamHandicap map[int]int // nil unless the format plays a team ball
+	teamSize     map[int]int
+}
+
+// loadRoundScorer prepares to score a round. It returns nil when the round
+// has no course.
+func loadRoundScorer(db *store.Store, round models.TournamentRound, scoring ScoringFormat, maxScore *models.MaxScore, players []models.Player) (*roundScorer, error) {
+	course, err := db.GetCourseByTournamentRoundID(round.ID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, nil
+	}
+
+	// Course handicaps depend on the tee ratings of this round's course
+	handicaps, err := loadRoundHandicaps(db, round, scoring, course, players)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &roundScorer{
+		scoring:   scoring,
+		course:    course,
+		holes:     make(map[int]models.HoleData),
+		holeCount: HoleCount(course.Meta.Holes),
+		maxScore:  maxScore,
+		handicaps: handicaps,
+		// Team ball formats play off a team handicap instead
+		teamHandicap: teamHandicaps(scoring, handicaps),
+		teamSize:     make(map[int]int),
+	}
+	for _, h := range course.Meta.Holes {
+		rs.holes[h.ID] = h
+	}
+	for _, p := range players {
+		rs.teamSize[p.TeamID]++
+	}
+	return rs, nil
+}
+
+// input turns a team's score entry into a scoring input with its handicap
+func (rs *roundScorer) input(teamID int, s models.Score) ScoreInput {
+	hcp := 0.0
+	if rs.teamHandicap != nil {
+		hcp = float64(rs.teamHandicap[teamID])
+	} else if s.PlayerID != nil {
+		if h, ok := rs.handicaps[*s.PlayerID]; ok {
+			hcp = float64(h.CourseHandicap)
+		}
+	}
+	return ScoreInput{Gross: s.Strokes, Handicap: hcp}
+}
+
+// scoreHole scores a team's entries on a hole. ok is false until every
+// entry the format needs has been posted.
+func (rs *roundScorer) scoreHole(teamID int, courseHoleID int, entries []models.Score) (int, int, bool) {
+	hole, ok := rs.holes[courseHoleID]
+	if !ok {
+		return 0, 0, false
+	}
+	if len(entries) == 0 || !holeComplete(rs.scoring, len(entries), rs.teamSize[teamID]) {
+		return 0, 0, false
+	}
+
+	inputs := make([]ScoreInput, 0, len(entries))
+	for _, s := range entries {
+		inputs = append(inputs, rs.input(teamID, s))
+	}
+	return hole.Number, CalculateHoleScore(rs.scoring, inputs, NewHoleContext(hole, rs.holeCount, rs.maxScore)), true
+}
+
+// results scores every team hole of a round that its scores complete
+func (rs *roundScorer) results(roundID int, scores []models.Score) []models.TeamHoleResult {
+	// Group Scores by Team -> Hole
+	entries := make(map[int]map[int][]models.Score)
+	for _, s := range scores {
+		if s.TeamID == nil {
+			continue
+		}
+		if _, ok := entries[*s.TeamID]; !ok {
+			entries[*s.TeamID] = make(map[int][]models.Score)
+		}
+		entries[*s.TeamID][s.CourseHoleID] = append(entries[*s.TeamID][s.CourseHoleID], s)
+	}
+
+	results := []models.TeamHoleResult{}
+	for teamID, holes := range entries {
+		for holeID, holeEntries := range holes {
+			number, score, ok := rs.scoreHole(teamID, holeID, holeEntries)
+			if !ok {
+				continue
+			}
+			results = append(results, models.TeamHoleResult{
+				RoundID:      roundID,
+				TeamID:       teamID,
+				CourseHoleID: holeID,
+				HoleNumber:   number,
+				Score:        score,
+			})
+		}
+	}
+	return results
+}
+
+// loadRoundContext resolves a round with its format, tournament and players
+func loadRoundContext(db *store.Store, roundID int) (*models.TournamentRound, ScoringFormat, *models.Tournament, []models.Player, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if round == nil {
+		return nil, nil, nil, nil, fmt.Errorf("round not found")
+	}
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	t, err := db.GetTournament(round.TournamentID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if t == nil {
+		return nil, nil, nil, nil, fmt.Errorf("tournament not found")
+	}
+	players, err := db.GetTournamentPlayers(round.TournamentID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return round, scoring, t, players, nil
+}
+
+// NewRoundScorer returns the scorer the store uses to keep team hole results
+// current as scores are submitted
+func NewRoundScorer(db *store.Store) store.RoundScorer {
+	return func(roundID int) (store.HoleScorer, error) {
+		round, scoring, t, players, err := loadRoundContext(db, roundID)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := loadRoundScorer(db, *round, scoring, t.MaxScore, players)
+		if err != nil || rs == nil {
+			return nil, err
+		}
+		return rs.scoreHole, nil
+	}
+}
+
+// RebuildRoundResults rescores every team hole of a round from its raw
+// scores, for when format rules or handicaps change. It returns the number of
+// results stored.
+func RebuildRoundResults(ctx context.Context, db *store.Store, roundID int) (int, error) {
+	round, scoring, t, players, err := loadRoundContext(db, roundID)
+	if err != nil {
+		return 0, err
+	}
+	rs, err := loadRoundScorer(db, *round, scoring, t.MaxScore, players)
+	if err != nil {
+		return 0, err
+	}
+
+	results := []models.TeamHoleResult{}
+	if rs != nil {
+		scores, err := db.GetRoundScores(roundID, nil, nil)
+		if err != nil {
+			return 0, err
+		}
+		results = rs.results(roundID, scores)
+	}
+
+	if err := db.ReplaceRoundResultsTx(roundID, results); err != nil {
+		return 0, err
+	}
+	return len(results), nil
+}
+
+// RebuildTournamentResults rescores every round of a tournament
+func RebuildTournamentResults(ctx context.Context, db *store.Store, tournamentID int) error {
+	rounds, err := db.GetTournamentRounds(tournamentID)
+	if err != nil {
+		return err
+	}
+	for _, r := range rounds {
+		if _, err := RebuildRoundResults(ctx, db, r.ID); err != nil {
+			return fmt.Errorf("round %d: %w", r.ID, err)
+		}
+	}
+	return nil
+}
+
+// BackfillRoundResults scores the rounds that have scores but no team hole
+// results, so leaderboards read them. It returns the number of rounds
+// rebuilt.
+func BackfillRoundResults(ctx context.Context, db *store.Store) (int, error) {
+	rounds, err := db.GetUnscoredRounds()
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range rounds {
+		if _, err := RebuildRoundResults(ctx, db, id); err != nil {
+			return 0, fmt.Errorf("round %d: %w", id, err)
+		}
+	}
+	return len(rounds), nil
+}
@@ -0,0 +1,424 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// RewardStanding is a player's, team's or group's place in the race for a
+// reward
+type RewardStanding struct {
+	Position      int     `json:"position"`
+	PositionLabel string  `json:"positionLabel"`
+	ID            int     `json:"id"` // Player, team or group ID, per the reward's scope
+	Name          string  `json:"name"`
+	Value         float64 `json:"value"`
+}
+
+// RewardResult is the state of a tournament reward. Leaders hold the reward
+// now; once every round is complete they are its Winners.
+type RewardResult struct {
+	RewardID    int64            `json:"rewardId"`
+	Scope       string           `json:"scope"`
+	Metric      string           `json:"metric"`
+	Description string           `json:"description,omitempty"`
+	Final       bool             `json:"final"`
+	Leaders     []RewardStanding `json:"leaders"`
+	Winners     []RewardStanding `json:"winners"`
+	Standings   []RewardStanding `json:"standings"`
+	// Error says why the reward cannot be decided, e.g. an unknown metric
+	Error string `json:"error,omitempty"`
+}
+
+// RewardMetric is a statistic a reward can be decided on
+type RewardMetric struct {
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes"`
+	// Counting metrics have no leader until somebody scores
+	counting bool
+	compute  func(in *rewardInput, scope string) ([]RewardStanding, SortDirection)
+}
+
+// Supports reports whether the metric can be awarded to a scope
+func (m RewardMetric) Supports(scope string) bool {
+	for _, s := range m.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RewardMetrics is the catalogue of metrics rewards can be decided on
+var RewardMetrics = []RewardMetric{
+	{
+		Code:        "total_score",
+		Name:        "Total Score",
+		Description: "Best tournament score. Teams that missed the cut are not eligible.",
+		Scopes:      []string{models.RewardScopeTeam, models.RewardScopeGroup},
+		compute:     totalScoreStandings,
+	},
+	{
+		Code:        "round_wins",
+		Name:        "Round Wins",
+		Description: "Most completed stroke play rounds won. Tied rounds count for every tied winner.",
+		Scopes:      []string{models.RewardScopeTeam, models.RewardScopeGroup},
+		counting:    true,
+		compute:     roundWinStandings,
+	},
+	{
+		Code:        "most_birdies",
+		Name:        "Most Birdies",
+		Description: "Most gross birdies or better, across every ball posted.",
+		Scopes:      []string{models.RewardScopePlayer, models.RewardScopeTeam, models.RewardScopeGroup},
+		counting:    true,
+		compute:     birdieStandings,
+	},
+	{
+		Code:        "low_round",
+		Name:        "Low Round",
+		Description: "Best single stroke play round, once every hole of it is in.",
+		Scopes:      []string{models.RewardScopeTeam},
+		compute:     lowRoundStandings,
+	},
+	{
+		Code:        "low_net_individual",
+		Name:        "Low Net Individual",
+		Description: "Lowest individual net score to par on each player's own ball.",
+		Scopes:      []string{models.RewardScopePlayer},
+		compute:     lowNetStandings,
+	},
+	{
+		Code:        "group_points",
+		Name:        "Group Points",
+		Description: "Most match play points won by a group's teams.",
+		Scopes:      []string{models.RewardScopeGroup},
+		counting:    true,
+		compute:     groupPointStandings,
+	},
+}
+
+// LookupRewardMetric returns the metric with a code, or nil if there is none
+func LookupRewardMetric(code string) *RewardMetric {
+	for i := range RewardMetrics {
+		if RewardMetrics[i].Code == code {
+			return &RewardMetrics[i]
+		}
+	}
+	return nil
+}
+
+// rewardRound is what the reward metrics need to know about a round
+type rewardRound struct {
+	round     models.TournamentRound
+	direction SortDirection
+	holes     int
+	par       map[int]int // by course hole ID
+}
+
+// rewardInput is everything rewards are computed from
+type rewardInput struct {
+	board      *LeaderboardResponse
+	rounds     map[int]*rewardRound
+	scores     []models.Score
+	playerTeam map[int]int
+	teamGroup  map[int]int
+}
+
+// CalculateRewards computes the standings of every reward of a tournament
+func CalculateRewards(ctx context.Context, db *store.Store, cache *infra.CacheManager, tournamentID int) ([]RewardResult, error) {
+	rewards, err := db.GetTournamentRewards(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := CalculateLeaderboard(ctx, db, cache, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &rewardInput{
+		board:      board,
+		rounds:     make(map[int]*rewardRound),
+		playerTeam: make(map[int]int),
+		teamGroup:  make(map[int]int),
+	}
+
+	rounds, err := db.GetTournamentRounds(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	final := len(rounds) > 0
+	for _, r := range rounds {
+		final = final && r.Status == "completed"
+
+		scoring, err := resolveRoundFormat(db, r)
+		if err != nil {
+			return nil, err
+		}
+		rr := &rewardRound{round: r, direction: scoring.SortDirection(), par: make(map[int]int)}
+		course, err := db.GetCourseByTournamentRoundID(r.ID)
+		if err != nil {
+			return nil, err
+		}
+		if course != nil {
+			rr.holes = HoleCount(course.Meta.Holes)
+			for _, h := range course.Meta.Holes {
+				rr.par[h.ID] = h.Par
+			}
+		}
+		in.rounds[r.ID] = rr
+	}
+
+	if in.scores, err = db.GetTournamentScores(tournamentID, nil, nil); err != nil {
+		return nil, err
+	}
+
+	players, err := db.GetTournamentPlayers(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range players {
+		in.playerTeam[p.ID] = p.TeamID
+	}
+	members, err := db.GetTournamentGroupMembers(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		in.teamGroup[int(m.TeamID)] = int(m.GroupID)
+	}
+
+	results := []RewardResult{}
+	for _, r := range rewards {
+		result := RewardResult{
+			RewardID:    r.ID,
+			Scope:       r.Scope,
+			Metric:      r.Metric,
+			Description: r.Description,
+			Final:       final,
+			Leaders:     []RewardStanding{},
+			Winners:     []RewardStanding{},
+			Standings:   []RewardStanding{},
+		}
+
+		metric := LookupRewardMetric(r.Metric)
+		if metric == nil || !metric.Supports(r.Scope) {
+			// Flag it rather than fail the other rewards
+			result.Error = fmt.Sprintf("metric %s is not awarded to a %s", r.Metric, r.Scope)
+			results = append(results, result)
+			continue
+		}
+
+		standings, direction := metric.compute(in, r.Scope)
+		result.Standings = rankStandings(standings, direction)
+
+		for _, s := range result.Standings {
+			if s.Position != 1 || (metric.counting && s.Value == 0) {
+				break
+			}
+			result.Leaders = append(result.Leaders, s)
+		}
+		if final {
+			result.Winners = result.Leaders
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// rankStandings orders standings best first; equal values share a position
+func rankStandings(standings []RewardStanding, direction SortDirection) []RewardStanding {
+	better := func(a, b float64) bool {
+		if direction == SortDescending {
+			return a > b
+		}
+		return a < b
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Value != standings[j].Value {
+			return better(standings[i].Value, standings[j].Value)
+		}
+		return standings[i].Name < standings[j].Name
+	})
+
+	for i := range standings {
+		first := i
+		for first > 0 && standings[first-1].Value == standings[i].Value {
+			first--
+		}
+		standings[i].Position = first + 1
+	}
+	for i := range standings {
+		shared := (i > 0 && standings[i-1].Position == standings[i].Position) ||
+			(i+1 < len(standings) && standings[i+1].Position == standings[i].Position)
+		standings[i].PositionLabel = positionLabel(standings[i].Position, shared)
+	}
+	return standings
+}
+
+// -- Metrics --
+
+func totalScoreStandings(in *rewardInput, scope string) ([]RewardStanding, SortDirection) {
+	standings := []RewardStanding{}
+	if scope == models.RewardScopeGroup {
+		for _, g := range in.board.Groups {
+			if g.Thru > 0 {
+				standings = append(standings, RewardStanding{ID: g.GroupID, Name: g.GroupName, Value: float64(g.Score)})
+			}
+		}
+		return standings, in.board.SortDirection
+	}
+	for _, e := range in.board.Teams {
+		if e.Thru > 0 && !e.MissedCut {
+			standings = append(standings, RewardStanding{ID: e.TeamID, Name: e.TeamName, Value: float64(e.Score)})
+		}
+	}
+	return standings, in.board.SortDirection
+}
+
+func roundWinStandings(in *rewardInput, scope string) ([]RewardStanding, SortDirection) {
+	type contender struct {
+		id     int
+		name   string
+		rounds []RoundScore
+	}
+	contenders := []contender{}
+	if scope == models.RewardScopeGroup {
+		for _, g := range in.board.Groups {
+			contenders = append(contenders, contender{g.GroupID, g.GroupName, g.Rounds})
+		}
+	} else {
+		for _, e := range in.board.Teams {
+			contenders = append(contenders, contender{e.TeamID, e.TeamName, e.Rounds})
+		}
+	}
+
+	// Best score of each completed round
+	best := make(map[int]int)
+	for _, c := range contenders {
+		for _, rs := range c.rounds {
+			rr := in.rounds[rs.RoundID]
+			if rr == nil || rr.round.Status != "completed" || rs.Thru == 0 {
+				continue
+			}
+			if b, ok := best[rs.RoundID]; !ok || rr.direction.Better(rs.Score, b) {
+				best[rs.RoundID] = rs.Score
+			}
+		}
+	}
+
+	standings := []RewardStanding{}
+	for _, c := range contenders {
+		wins := 0
+		for _, rs := range c.rounds {
+			if b, ok := best[rs.RoundID]; ok && rs.Thru > 0 && rs.Score == b {
+				wins++
+			}
+		}
+		standings = append(standings, RewardStanding{ID: c.id, Name: c.name, Value: float64(wins)})
+	}
+	return standings, SortDescending
+}
+
+func birdieStandings(in *rewardInput, scope string) ([]RewardStanding, SortDirection) {
+	counts := make(map[int]int)
+	for _, s := range in.scores {
+		if s.TournamentRoundID == nil {
+			continue
+		}
+		rr := in.rounds[*s.TournamentRoundID]
+		if rr == nil {
+			continue
+		}
+		par, ok := rr.par[s.CourseHoleID]
+		if !ok || s.Strokes <= 0 || s.Strokes >= par {
+			continue
+		}
+
+		// Team balls count toward their team and group only
+		teamID := 0
+		if s.TeamID != nil {
+			teamID = *s.TeamID
+		} else if s.PlayerID != nil {
+			teamID = in.playerTeam[*s.PlayerID]
+		}
+		switch scope {
+		case models.RewardScopePlayer:
+			if s.PlayerID != nil {
+				counts[*s.PlayerID]++
+			}
+		case models.RewardScopeTeam:
+			counts[teamID]++
+		case models.RewardScopeGroup:
+			if groupID, ok := in.teamGroup[teamID]; ok {
+				counts[groupID]++
+			}
+		}
+	}
+
+	standings := []RewardStanding{}
+	switch scope {
+	case models.RewardScopePlayer:
+		for _, p := range in.board.PlayersGross {
+			standings = append(standings, RewardStanding{ID: p.PlayerID, Name: p.Name, Value: float64(counts[p.PlayerID])})
+		}
+	case models.RewardScopeTeam:
+		for _, e := range in.board.Teams {
+			standings = append(standings, RewardStanding{ID: e.TeamID, Name: e.TeamName, Value: float64(counts[e.TeamID])})
+		}
+	case models.RewardScopeGroup:
+		for _, g := range in.board.Groups {
+			standings = append(standings, RewardStanding{ID: g.GroupID, Name: g.GroupName, Value: float64(counts[g.GroupID])})
+		}
+	}
+	return standings, SortDescending
+}
+
+func lowRoundStandings(in *rewardInput, scope string) ([]RewardStanding, SortDirection) {
+	direction := in.board.SortDirection
+	standings := []RewardStanding{}
+	for _, e := range in.board.Teams {
+		found := false
+		low := 0
+		for _, rs := range e.Rounds {
+			rr := in.rounds[rs.RoundID]
+			if rr == nil || rr.holes == 0 || rs.Thru < rr.holes {
+				continue
+			}
+			if !found || direction.Better(rs.Score, low) {
+				low = rs.Score
+				found = true
+			}
+		}
+		if found {
+			standings = append(standings, RewardStanding{ID: e.TeamID, Name: e.TeamName, Value: float64(low)})
+		}
+	}
+	return standings, direction
+}
+
+func lowNetStandings(in *rewardInput, scope string) ([]RewardStanding, SortDirection) {
+	standings := []RewardStanding{}
+	for _, p := range in.board.PlayersNet {
+		if p.Thru > 0 {
+			standings = append(standings, RewardStanding{ID: p.PlayerID, Name: p.Name, Value: float64(p.Net)})
+		}
+	}
+	return standings, SortAscending
+}
+
+func groupPointStandings(in *rewardInput, scope string) ([]RewardStanding, SortDirection) {
+	standings := []RewardStanding{}
+	for _, g := range in.board.Groups {
+		standings = append(standings, RewardStanding{ID: g.GroupID, Name: g.GroupName, Value: g.MatchPoints})
+	}
+	return standings, SortDescending
+}
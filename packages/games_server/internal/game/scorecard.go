@@ -0,0 +1,303 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// ScorecardEntry is a single ball posted on a hole
+type ScorecardEntry struct {
+	PlayerID *int   `json:"playerId,omitempty"` // nil for a team ball
+	Name     string `json:"name"`
+	Gross    int    `json:"gross"`
+	Adjusted int    `json:"adjusted"` // Gross capped at the maximum hole score
+	Strokes  int    `json:"strokes"`  // Handicap dots received on the hole
+	Net      int    `json:"net"`      // Adjusted gross less strokes received
+	Score    int    `json:"score"`    // The ball's score in the format: to par, or points
+	Counted  bool   `json:"counted"`  // Set when the ball counts toward the hole score
+}
+
+// ScorecardHole is a hole on a scorecard. Score and Running are nil until
+// the hole is complete.
+type ScorecardHole struct {
+	HoleNumber  int              `json:"holeNumber"`
+	Par         int              `json:"par"`
+	StrokeIndex int              `json:"strokeIndex"`
+	Yardage     int              `json:"yardage,omitempty"`
+	Entries     []ScorecardEntry `json:"entries"`
+	Score       *int             `json:"score"`   // Counting score for the format
+	Running     *int             `json:"running"` // Running to par, or points
+}
+
+// ScorecardTotals sums the completed holes of a stretch of the card.
+// Gross and Net total the balls that counted.
+type ScorecardTotals struct {
+	Par   int `json:"par"`
+	Gross int `json:"gross"`
+	Net   int `json:"net"`
+	Score int `json:"score"`
+	Thru  int `json:"thru"`
+}
+
+// Scorecard is a team's or player's hole-by-hole card for a round
+type Scorecard struct {
+	RoundID       int              `json:"roundId"`
+	TeamID        int              `json:"teamId"`
+	PlayerID      *int             `json:"playerId,omitempty"`
+	Name          string           `json:"name"`
+	FormatCode    string           `json:"formatCode"`
+	SortDirection SortDirection    `json:"sortDirection"`
+	TeamHandicap  *int             `json:"teamHandicap,omitempty"` // Team ball formats only
+	Players       []PlayerHandicap `json:"players"`
+	Holes         []ScorecardHole  `json:"holes"`
+	Front         *ScorecardTotals `json:"front,omitempty"` // 18-hole rounds only
+	Back          *ScorecardTotals `json:"back,omitempty"`  // 18-hole rounds only
+	Total         ScorecardTotals  `json:"total"`
+}
+
+// countedEntries marks the entries that make up a hole's score: the best
+// ScoresToCount balls for best-n formats, and every ball otherwise.
+func countedEntries(format ScoringFormat, scores []int) []bool {
+	counted := make([]bool, len(scores))
+	count := len(scores)
+	if format.Aggregation() != AggregateAll && format.ScoresToCount() > 0 && format.ScoresToCount() < count {
+		count = format.ScoresToCount()
+	}
+
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return format.SortDirection().Better(scores[order[i]], scores[order[j]])
+	})
+	for _, idx := range order[:count] {
+		counted[idx] = true
+	}
+	return counted
+}
+
+// BuildScorecard builds the scorecard of a team, or of a single player when
+// playerID is set, for a round. It returns nil when the team or player is not
+// in the round's tournament.
+func BuildScorecard(ctx context.Context, db *store.Store, roundID int, teamID *int, playerID *int) (*Scorecard, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+
+	tournament, err := db.GetTournament(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == nil {
+		return nil, fmt.Errorf("tournament not found")
+	}
+
+	players, err := db.GetTournamentPlayers(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &Scorecard{
+		RoundID:       roundID,
+		FormatCode:    scoring.Code(),
+		SortDirection: scoring.SortDirection(),
+		Players:       []PlayerHandicap{},
+		Holes:         []ScorecardHole{},
+	}
+
+	// Resolve whose card this is
+	members := []models.Player{}
+	if playerID != nil {
+		for _, p := range players {
+			if p.ID == *playerID {
+				members = append(members, p)
+				card.TeamID = p.TeamID
+				card.PlayerID = playerID
+				card.Name = p.Name
+			}
+		}
+	} else if teamID != nil {
+		team, err := db.GetTeam(*teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil || team.TournamentID != round.TournamentID {
+			return nil, nil
+		}
+		card.TeamID = team.ID
+		card.Name = team.Name
+		for _, p := range players {
+			if p.TeamID == team.ID {
+				members = append(members, p)
+			}
+		}
+	}
+	if card.TeamID == 0 {
+		return nil, nil
+	}
+
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return card, nil
+	}
+
+	handicaps, err := loadRoundHandicaps(db, *round, scoring, course, players)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range members {
+		card.Players = append(card.Players, handicaps[p.ID])
+	}
+
+	// A player's card scores their own ball; a team card scores the team's balls
+	teamBall := playerID == nil && scoring.Aggregation() == AggregateTeamBall
+	if teamBall {
+		if th := teamHandicaps(scoring, handicaps); th != nil {
+			v := th[card.TeamID]
+			card.TeamHandicap = &v
+		}
+	}
+
+	var scores []models.Score
+	if playerID != nil {
+		scores, err = db.GetRoundScores(roundID, playerID, nil)
+	} else {
+		scores, err = db.GetRoundScores(roundID, nil, &card.TeamID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byHole := make(map[int][]models.Score)
+	for _, s := range scores {
+		byHole[s.CourseHoleID] = append(byHole[s.CourseHoleID], s)
+	}
+
+	holes := make([]models.HoleData, len(course.Meta.Holes))
+	copy(holes, course.Meta.Holes)
+	sort.Slice(holes, func(i, j int) bool {
+		return holes[i].Number < holes[j].Number
+	})
+	holeCount := HoleCount(holes)
+
+	names := make(map[int]string)
+	for _, p := range players {
+		names[p.ID] = p.Name
+	}
+
+	teamSize := len(members)
+	if playerID != nil {
+		teamSize = 1
+	}
+
+	var front, back ScorecardTotals
+	running := 0
+	for _, h := range holes {
+		hole := NewHoleContext(h, holeCount, tournament.MaxScore)
+		if hole.Allowance == 0 {
+			hole.Allowance = scoring.DefaultAllowance()
+		}
+		allowance := hole.Allowance
+		if teamBall {
+			// Team handicap weights already include the allowance
+			allowance = 1.0
+		}
+
+		half := &front
+		if h.Number > RegulationHoles/2 {
+			half = &back
+		}
+
+		entry := ScorecardHole{
+			HoleNumber:  h.Number,
+			Par:         h.Par,
+			StrokeIndex: h.Handicap,
+			Yardage:     h.Yardage,
+			Entries:     []ScorecardEntry{},
+		}
+
+		inputs := []ScoreInput{}
+		ballScores := []int{}
+		for _, s := range byHole[h.ID] {
+			input := ScoreInput{Gross: s.Strokes}
+			name := card.Name
+			if teamBall && card.TeamHandicap != nil {
+				input.Handicap = float64(*card.TeamHandicap)
+			} else if s.PlayerID != nil {
+				input.Handicap = float64(handicaps[*s.PlayerID].CourseHandicap)
+			}
+			if s.PlayerID != nil {
+				name = names[*s.PlayerID]
+			}
+
+			strokes := hole.Strokes(input, allowance)
+			adjusted := hole.Adjusted(s.Strokes, strokes)
+			ball := CalculateHoleScore(scoring, []ScoreInput{input}, hole)
+
+			inputs = append(inputs, input)
+			ballScores = append(ballScores, ball)
+			entry.Entries = append(entry.Entries, ScorecardEntry{
+				PlayerID: s.PlayerID,
+				Name:     name,
+				Gross:    s.Strokes,
+				Adjusted: adjusted,
+				Strokes:  strokes,
+				Net:      adjusted - strokes,
+				Score:    ball,
+			})
+		}
+
+		if len(inputs) > 0 && holeComplete(scoring, len(inputs), teamSize) {
+			score := CalculateHoleScore(scoring, inputs, hole)
+			running += score
+			r := running
+			entry.Score = &score
+			entry.Running = &r
+
+			gross, net := 0, 0
+			for i, counted := range countedEntries(scoring, ballScores) {
+				entry.Entries[i].Counted = counted
+				if counted {
+					gross += entry.Entries[i].Adjusted
+					net += entry.Entries[i].Net
+				}
+			}
+
+			for _, t := range []*ScorecardTotals{&card.Total, half} {
+				t.Gross += gross
+				t.Net += net
+				t.Score += score
+				t.Thru++
+			}
+		}
+
+		card.Total.Par += h.Par
+		half.Par += h.Par
+
+		card.Holes = append(card.Holes, entry)
+	}
+
+	if holeCount == RegulationHoles {
+		card.Front = &front
+		card.Back = &back
+	}
+
+	return card, nil
+}
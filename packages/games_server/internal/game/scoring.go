@@ -2,97 +2,113 @@ package game
 
 import (
 	"math"
-	"sort"
-	"strings"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
 )
 
+// RegulationHoles is the hole count handicaps are expressed over
+const RegulationHoles = 18
+
 type ScoreInput struct {
 	Gross    int
 	Handicap float64
 }
 
-// CalculateNetScore computes the net score relative to par for a player on a specific hole
-// handicap: The player's handicap index (or course handicap)
-// allowance: The percentage of handicap to use (e.g., 1.0, 0.8). If 0, assumes 1.0.
-// par: The par for the hole
-// strokeIndex: The difficulty rating of the hole (1-18)
-func CalculateNetScore(gross int, handicap float64, allowance float64, par int, strokeIndex int) int {
-	if allowance == 0 {
-		allowance = 1.0
+// HoleContext is a hole being scored and the handicap rules in play for it
+type HoleContext struct {
+	Par         int
+	StrokeIndex int // Ranked over the holes in the round, 1 = hardest
+	HoleCount   int // Holes in the round; strokes are allocated over these
+	Allowance   float64
+	MaxScore    *models.MaxScore // nil counts every stroke
+}
+
+// NewHoleContext builds the scoring context for a hole in a round of holeCount holes
+func NewHoleContext(hole models.HoleData, holeCount int, maxScore *models.MaxScore) HoleContext {
+	return HoleContext{
+		Par:         hole.Par,
+		StrokeIndex: hole.Handicap,
+		HoleCount:   holeCount,
+		Allowance:   hole.AllowedHandicap,
+		MaxScore:    maxScore,
 	}
-	playingHandicap := math.Round(handicap * allowance)
+}
 
-	// Strokes received
-	strokes := int(playingHandicap) / 18
-	remainder := int(playingHandicap) % 18
+// Strokes returns the handicap strokes a score receives on the hole
+func (h HoleContext) Strokes(s ScoreInput, allowance float64) int {
+	return StrokesReceived(PlayingHandicap(int(math.Round(s.Handicap)), allowance), h.StrokeIndex, h.HoleCount)
+}
 
-	if strokeIndex <= remainder {
-		strokes++
+// Adjusted caps a gross score at the hole's maximum for a player receiving strokes
+func (h HoleContext) Adjusted(gross int, strokes int) int {
+	if limit := MaxHoleScore(h.MaxScore, h.Par, strokes); limit > 0 && gross > limit {
+		return limit
 	}
-
-	return (gross - strokes) - par
+	return gross
 }
 
-// CalculateHoleScore computes the team score for a hole based on the format
-// Returns the score relative to par (e.g., -1 for birdie, 0 for par)
-func CalculateHoleScore(format string, scores []ScoreInput, par int, strokeIndex int, allowance float64) int {
-	lowerFormat := strings.ToLower(format)
+// Net returns a capped score net of handicap strokes, relative to par
+func (h HoleContext) Net(s ScoreInput, allowance float64) int {
+	strokes := h.Strokes(s, allowance)
+	return h.Adjusted(s.Gross, strokes) - strokes - h.Par
+}
 
-	// Scramble / Alternate Shot: Team score is the single gross score (minus par)
-	// Usually there is only one score entry per team.
-	if strings.Contains(lowerFormat, "scramble") || strings.Contains(lowerFormat, "alternate shot") {
-		if len(scores) == 0 {
-			return 0
-		}
-		// In case multiple scores exist (e.g. data error), take the lowest
-		minGross := scores[0].Gross
-		for _, s := range scores {
-			if s.Gross < minGross {
-				minGross = s.Gross
-			}
-		}
-		return minGross - par
+// MaxHoleScore returns the most strokes that count on a hole, or 0 for no cap
+func MaxHoleScore(m *models.MaxScore, par int, strokes int) int {
+	if m == nil {
+		return 0
 	}
-
-	// Calculate Net Scores for all individual scores provided
-	netScores := make([]int, len(scores))
-	for i, s := range scores {
-		netScores[i] = CalculateNetScore(s.Gross, s.Handicap, allowance, par, strokeIndex)
+	switch m.Rule {
+	case models.MaxScoreNetDoubleBogey:
+		return par + 2 + strokes
+	case models.MaxScoreDoublePar:
+		return par * 2
+	case models.MaxScoreFixed:
+		return m.Value
 	}
+	return 0
+}
 
-	if len(netScores) == 0 {
-		return 0
+// StrokesReceived returns the handicap strokes a player gets on a hole.
+// Strokes are spread over holeCount holes starting at stroke index 1; plus
+// (negative) handicaps give strokes back starting at the easiest hole.
+func StrokesReceived(playingHandicap int, strokeIndex int, holeCount int) int {
+	if holeCount <= 0 {
+		holeCount = RegulationHoles
 	}
 
-	// Combined Score: Sum of all net scores
-	// Note: "2-Man Best Ball (Combined)" will fall into the "Best Ball" logic below with scoresToCount = 2
-	// unless explicitly "Combined Score" which implies ALL players.
-	if strings.Contains(lowerFormat, "combined score") {
-		sum := 0
-		for _, net := range netScores {
-			sum += net
+	if playingHandicap < 0 {
+		given := -playingHandicap
+		strokes := -(given / holeCount)
+		if strokeIndex > holeCount-given%holeCount {
+			strokes--
 		}
-		return sum
+		return strokes
 	}
 
-	// Best Ball Logic
-	// Default to counting the best 1 score (e.g., "Best Ball", "4-Man Best Ball" renamed to just "Best Ball")
-	scoresToCount := 1
-
-	// "2-Man Best Ball (Combined)" - Sum of top 2 net scores
-	// We check for "2-man" and "combined" in the name to trigger the count=2 logic.
-	// Or if the legacy "2-Man Best Ball" name is still present and implies combined (as per previous logic).
-	// To be safe and explicit:
-	if strings.Contains(lowerFormat, "2-man") && (strings.Contains(lowerFormat, "best ball") || strings.Contains(lowerFormat, "combined")) {
-		scoresToCount = 2
+	strokes := playingHandicap / holeCount
+	if strokeIndex <= playingHandicap%holeCount {
+		strokes++
 	}
+	return strokes
+}
 
-	sort.Ints(netScores)
+// CalculateNetScore computes the net score relative to par for a player on a specific hole
+// handicap: The player's course handicap (see CourseHandicap)
+// allowance: The percentage of handicap to use (e.g., 1.0, 0.8). If 0, assumes 1.0.
+// par: The par for the hole
+// strokeIndex: The difficulty rating of the hole (1 to holeCount)
+// holeCount: The number of holes in the round. If 0, assumes 18.
+func CalculateNetScore(gross int, handicap float64, allowance float64, par int, strokeIndex int, holeCount int) int {
+	hole := HoleContext{Par: par, StrokeIndex: strokeIndex, HoleCount: holeCount}
+	return hole.Net(ScoreInput{Gross: gross, Handicap: handicap}, allowance)
+}
 
-	total := 0
-	for i := 0; i < scoresToCount && i < len(netScores); i++ {
-		total += netScores[i]
+// CalculateHoleScore computes the team score for a hole based on the format
+// Returns the score relative to par (e.g., -1 for birdie, 0 for par)
+func CalculateHoleScore(format ScoringFormat, scores []ScoreInput, hole HoleContext) int {
+	if hole.Allowance == 0 {
+		hole.Allowance = format.DefaultAllowance()
 	}
-
-	return total
+	return format.ScoreHole(scores, hole)
 }
@@ -0,0 +1,102 @@
+package game
+
+import "sort"
+
+// PointTable maps a hole score relative to par to Stableford points
+type PointTable struct {
+	Albatross   int `json:"albatross"` // -3 or better
+	Eagle       int `json:"eagle"`
+	Birdie      int `json:"birdie"`
+	Par         int `json:"par"`
+	Bogey       int `json:"bogey"`
+	DoubleBogey int `json:"doubleBogey"`
+	Worse       int `json:"worse"` // Triple bogey or worse
+}
+
+// StablefordPoints is the standard Stableford table
+var StablefordPoints = PointTable{
+	Albatross:   5,
+	Eagle:       4,
+	Birdie:      3,
+	Par:         2,
+	Bogey:       1,
+	DoubleBogey: 0,
+	Worse:       0,
+}
+
+// ModifiedStablefordPoints is the PGA Tour modified Stableford table
+var ModifiedStablefordPoints = PointTable{
+	Albatross:   8,
+	Eagle:       5,
+	Birdie:      2,
+	Par:         0,
+	Bogey:       -1,
+	DoubleBogey: -3,
+	Worse:       -3,
+}
+
+// Points returns the points earned for a score relative to par
+func (t PointTable) Points(toPar int) int {
+	switch {
+	case toPar <= -3:
+		return t.Albatross
+	case toPar == -2:
+		return t.Eagle
+	case toPar == -1:
+		return t.Birdie
+	case toPar == 0:
+		return t.Par
+	case toPar == 1:
+		return t.Bogey
+	case toPar == 2:
+		return t.DoubleBogey
+	default:
+		return t.Worse
+	}
+}
+
+// stablefordFormat scores each player's hole as points and counts the best
+// scoresToCount point totals for the team (0 = all).
+type stablefordFormat struct {
+	code          string
+	gross         bool
+	scoresToCount int
+	allowance     float64
+	points        PointTable
+}
+
+func (f stablefordFormat) Code() string                 { return f.code }
+func (f stablefordFormat) EntryMode() EntryMode         { return EntryPerPlayer }
+func (f stablefordFormat) ScoresToCount() int           { return f.scoresToCount }
+func (f stablefordFormat) DefaultAllowance() float64    { return f.allowance }
+func (f stablefordFormat) Aggregation() Aggregation     { return AggregateBestN }
+func (f stablefordFormat) SortDirection() SortDirection { return SortDescending }
+
+func (f stablefordFormat) ScoreHole(scores []ScoreInput, hole HoleContext) int {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	points := make([]int, len(scores))
+	for i, s := range scores {
+		toPar := hole.Adjusted(s.Gross, 0) - hole.Par
+		if !f.gross {
+			toPar = hole.Net(s, hole.Allowance)
+		}
+		points[i] = f.points.Points(toPar)
+	}
+
+	// Highest points first
+	sort.Sort(sort.Reverse(sort.IntSlice(points)))
+
+	count := len(points)
+	if f.scoresToCount > 0 && f.scoresToCount < count {
+		count = f.scoresToCount
+	}
+
+	total := 0
+	for i := 0; i < count; i++ {
+		total += points[i]
+	}
+	return total
+}
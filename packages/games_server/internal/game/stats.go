@@ -0,0 +1,242 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// ScoreCounts tallies gross hole scores against par
+type ScoreCounts struct {
+	Eagles     int `json:"eagles"` // Eagle or better
+	Birdies    int `json:"birdies"`
+	Pars       int `json:"pars"`
+	Bogeys     int `json:"bogeys"`
+	DoublePlus int `json:"doublePlus"` // Double bogey or worse
+	Holes      int `json:"holes"`
+}
+
+func (c *ScoreCounts) add(strokes, par int) {
+	switch diff := strokes - par; {
+	case diff <= -2:
+		c.Eagles++
+	case diff == -1:
+		c.Birdies++
+	case diff == 0:
+		c.Pars++
+	case diff == 1:
+		c.Bogeys++
+	default:
+		c.DoublePlus++
+	}
+	c.Holes++
+}
+
+type PlayerStats struct {
+	PlayerID int         `json:"playerId"`
+	Name     string      `json:"name"`
+	TeamID   int         `json:"teamId"`
+	Counts   ScoreCounts `json:"counts"`
+}
+
+// TeamStats counts every ball a team posted: its players' balls and any team
+// balls
+type TeamStats struct {
+	TeamID int         `json:"teamId"`
+	Name   string      `json:"name"`
+	Counts ScoreCounts `json:"counts"`
+}
+
+// HoleStats is how the field played a hole of a round. Difficulty ranks the
+// holes by scoring average to par, hardest first, to compare with the
+// hole's stroke index.
+type HoleStats struct {
+	HoleNumber  int         `json:"holeNumber"`
+	Par         int         `json:"par"`
+	StrokeIndex int         `json:"strokeIndex"`
+	Average     float64     `json:"average"` // Gross strokes per ball
+	ToPar       float64     `json:"toPar"`
+	Difficulty  int         `json:"difficulty"`
+	IndexDelta  int         `json:"indexDelta"` // StrokeIndex less Difficulty; positive when the hole played harder than rated
+	Counts      ScoreCounts `json:"counts"`
+}
+
+type RoundStats struct {
+	RoundID     int         `json:"roundId"`
+	RoundNumber int         `json:"roundNumber"`
+	Name        string      `json:"name"`
+	Counts      ScoreCounts `json:"counts"`
+	Holes       []HoleStats `json:"holes"`
+}
+
+// TournamentStats are the scoring statistics of a tournament, built on the
+// raw gross scores of every ball posted
+type TournamentStats struct {
+	TournamentID int           `json:"tournamentId"`
+	Counts       ScoreCounts   `json:"counts"`
+	Players      []PlayerStats `json:"players"`
+	Teams        []TeamStats   `json:"teams"`
+	Rounds       []RoundStats  `json:"rounds"`
+}
+
+// holeTotals accumulates the strokes posted on a hole
+type holeTotals struct {
+	hole    models.HoleData
+	strokes int
+	counts  ScoreCounts
+}
+
+// CalculateTournamentStats tallies the scores of a tournament per player,
+// team, round and hole
+func CalculateTournamentStats(ctx context.Context, db *store.Store, tournamentID int) (*TournamentStats, error) {
+	t, err := db.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("tournament not found")
+	}
+
+	teams, err := db.GetTeamsByTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	players, err := db.GetTournamentPlayers(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	rounds, err := db.GetTournamentRounds(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &TournamentStats{
+		TournamentID: tournamentID,
+		Players:      []PlayerStats{},
+		Teams:        []TeamStats{},
+		Rounds:       []RoundStats{},
+	}
+
+	playerIdx := make(map[int]int)
+	playerTeam := make(map[int]int)
+	for _, p := range players {
+		playerIdx[p.ID] = len(stats.Players)
+		playerTeam[p.ID] = p.TeamID
+		stats.Players = append(stats.Players, PlayerStats{PlayerID: p.ID, Name: p.Name, TeamID: p.TeamID})
+	}
+	teamIdx := make(map[int]int)
+	for _, tm := range teams {
+		teamIdx[tm.ID] = len(stats.Teams)
+		stats.Teams = append(stats.Teams, TeamStats{TeamID: tm.ID, Name: tm.Name})
+	}
+
+	for _, round := range rounds {
+		rs := RoundStats{
+			RoundID:     round.ID,
+			RoundNumber: round.RoundNumber,
+			Name:        round.Name,
+			Holes:       []HoleStats{},
+		}
+
+		course, err := db.GetCourseByTournamentRoundID(round.ID)
+		if err != nil {
+			return nil, err
+		}
+		if course == nil {
+			// Skip rounds without a course
+			stats.Rounds = append(stats.Rounds, rs)
+			continue
+		}
+
+		holes := make(map[int]*holeTotals)
+		for _, h := range course.Meta.Holes {
+			holes[h.ID] = &holeTotals{hole: h}
+		}
+
+		scores, err := db.GetRoundScores(round.ID, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range scores {
+			h, ok := holes[s.CourseHoleID]
+			if !ok || s.Strokes <= 0 {
+				continue
+			}
+			par := h.hole.Par
+
+			h.strokes += s.Strokes
+			h.counts.add(s.Strokes, par)
+			rs.Counts.add(s.Strokes, par)
+			stats.Counts.add(s.Strokes, par)
+
+			teamID := 0
+			if s.TeamID != nil {
+				teamID = *s.TeamID
+			}
+			if s.PlayerID != nil {
+				if i, ok := playerIdx[*s.PlayerID]; ok {
+					stats.Players[i].Counts.add(s.Strokes, par)
+				}
+				if teamID == 0 {
+					teamID = playerTeam[*s.PlayerID]
+				}
+			}
+			if i, ok := teamIdx[teamID]; ok {
+				stats.Teams[i].Counts.add(s.Strokes, par)
+			}
+		}
+
+		rs.Holes = rankHoleDifficulty(holes)
+		stats.Rounds = append(stats.Rounds, rs)
+	}
+
+	return stats, nil
+}
+
+// rankHoleDifficulty averages each hole and ranks the holes by how hard they
+// played. Holes nobody has played rank last, in stroke index order.
+func rankHoleDifficulty(holes map[int]*holeTotals) []HoleStats {
+	result := make([]HoleStats, 0, len(holes))
+	for _, h := range holes {
+		hs := HoleStats{
+			HoleNumber:  h.hole.Number,
+			Par:         h.hole.Par,
+			StrokeIndex: h.hole.Handicap,
+			Counts:      h.counts,
+		}
+		if h.counts.Holes > 0 {
+			average := float64(h.strokes) / float64(h.counts.Holes)
+			hs.Average = roundHundredth(average)
+			hs.ToPar = roundHundredth(average - float64(h.hole.Par))
+		}
+		result = append(result, hs)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.Counts.Holes > 0) != (b.Counts.Holes > 0) {
+			return a.Counts.Holes > 0
+		}
+		if a.ToPar != b.ToPar {
+			return a.ToPar > b.ToPar
+		}
+		return a.StrokeIndex < b.StrokeIndex
+	})
+	for i := range result {
+		result[i].Difficulty = i + 1
+		result[i].IndexDelta = result[i].StrokeIndex - result[i].Difficulty
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].HoleNumber < result[j].HoleNumber
+	})
+	return result
+}
+
+func roundHundredth(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// TeamHandicapWeights are the shares of each player's course handicap that make
+// up a team handicap, keyed by team size and applied lowest handicap first.
+type TeamHandicapWeights map[int][]float64
+
+var (
+	// ScrambleWeights follow the USGA recommendations for scrambles
+	ScrambleWeights = TeamHandicapWeights{
+		2: {0.35, 0.15},
+		3: {0.20, 0.15, 0.10},
+		4: {0.25, 0.20, 0.15, 0.10},
+	}
+	// FoursomesWeights give alternate shot pairs 50% of their combined handicap
+	FoursomesWeights = TeamHandicapWeights{
+		2: {0.50, 0.50},
+	}
+)
+
+// TeamHandicap combines the course handicaps of a team's players. Teams larger
+// than any configured size use the weights of the largest size below them.
+func (w TeamHandicapWeights) TeamHandicap(courseHandicaps []int) int {
+	size := 0
+	for n := range w {
+		if n <= len(courseHandicaps) && n > size {
+			size = n
+		}
+	}
+	if size == 0 {
+		return 0
+	}
+
+	sorted := make([]int, len(courseHandicaps))
+	copy(sorted, courseHandicaps)
+	sort.Ints(sorted)
+
+	total := 0.0
+	for i, weight := range w[size] {
+		if i >= len(sorted) {
+			break
+		}
+		total += float64(sorted[i]) * weight
+	}
+	return int(math.Round(total))
+}
+
+// TeamHandicapper is implemented by formats whose team ball plays off a team handicap
+type TeamHandicapper interface {
+	TeamHandicap(courseHandicaps []int) int
+}
+
+// TeamHandicap is the handicap a team plays off for a round
+type TeamHandicap struct {
+	TeamID       int              `json:"teamId"`
+	Name         string           `json:"name"`
+	Players      []PlayerHandicap `json:"players"`
+	TeamHandicap int              `json:"teamHandicap"`
+}
+
+// teamHandicaps maps each team to the handicap its team ball plays off.
+// It returns nil for formats scored on individual balls.
+func teamHandicaps(scoring ScoringFormat, handicaps map[int]PlayerHandicap) map[int]int {
+	th, ok := scoring.(TeamHandicapper)
+	if !ok || scoring.EntryMode() != EntryPerTeam {
+		return nil
+	}
+
+	byTeam := make(map[int][]int)
+	for _, h := range handicaps {
+		byTeam[h.TeamID] = append(byTeam[h.TeamID], h.CourseHandicap)
+	}
+
+	result := make(map[int]int)
+	for teamID, chs := range byTeam {
+		result[teamID] = th.TeamHandicap(chs)
+	}
+	return result
+}
+
+// CalculateTeamHandicaps returns the handicap each team plays off in a round
+func CalculateTeamHandicaps(ctx context.Context, db *store.Store, roundID int) ([]TeamHandicap, error) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	scoring, err := resolveRoundFormat(db, *round)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := db.GetCourseByTournamentRoundID(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return []TeamHandicap{}, nil
+	}
+
+	tees, err := db.GetCourseTees(course.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := db.GetTeamsByTournament(round.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	th, _ := scoring.(TeamHandicapper)
+	results := []TeamHandicap{}
+	for _, t := range teams {
+		players, err := db.GetTeamPlayers(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		if players, err = roundPlayers(db, round.ID, players); err != nil {
+			return nil, err
+		}
+		handicaps := RoundHandicaps(players, tees, course, roundAllowance(*round, scoring))
+
+		entry := TeamHandicap{TeamID: t.ID, Name: t.Name, Players: []PlayerHandicap{}}
+		chs := []int{}
+		for _, p := range players {
+			entry.Players = append(entry.Players, handicaps[p.ID])
+			chs = append(chs, handicaps[p.ID].CourseHandicap)
+		}
+		if th != nil && scoring.EntryMode() == EntryPerTeam {
+			entry.TeamHandicap = th.TeamHandicap(chs)
+		}
+		results = append(results, entry)
+	}
+	return results, nil
+}
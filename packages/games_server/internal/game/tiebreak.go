@@ -0,0 +1,172 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+)
+
+// DefaultCountbackHoles are the segments compared in a countback: the last 9,
+// last 6, last 3 and last hole of the final round
+var DefaultCountbackHoles = []int{9, 6, 3, 1}
+
+// rankedEntry is what a leaderboard row is ranked on
+type rankedEntry struct {
+	Score int
+	Thru  int
+	Name  string
+	// Playoff is the team's place in a playoff, 0 if it did not play one
+	Playoff int
+	// Countback holds the segment totals of the final round, nil when the
+	// round is unfinished
+	Countback []int
+	// MissedCut entries rank below every entry that made the cut
+	MissedCut bool
+}
+
+// countbackSegments returns the countback holes for a tournament, or nil when
+// ties are left to stand
+func countbackSegments(t *models.Tiebreak) []int {
+	if t == nil {
+		return DefaultCountbackHoles
+	}
+	if t.Method == models.TiebreakNone {
+		return nil
+	}
+	if len(t.Holes) == 0 {
+		return DefaultCountbackHoles
+	}
+	return t.Holes
+}
+
+// countbackTotals sums a round's hole scores over the last n holes of each
+// segment. Hole scores are net of handicap strokes in net formats, so the
+// countback is handicap-adjusted. Unfinished rounds return nil.
+func countbackTotals(rs *TeamRoundStats, segments []int) []int {
+	if rs == nil || len(segments) == 0 || rs.RoundHoles == 0 || len(rs.Holes) < rs.RoundHoles {
+		return nil
+	}
+
+	numbers := make([]int, 0, len(rs.Holes))
+	for n := range rs.Holes {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	totals := make([]int, len(segments))
+	for i, n := range segments {
+		start := len(numbers) - n
+		if start < 0 {
+			start = 0
+		}
+		for _, number := range numbers[start:] {
+			totals[i] += rs.Holes[number]
+		}
+	}
+	return totals
+}
+
+// rankPositions returns the order of entries, best first, and the position
+// of each entry in that order. Entries that missed the cut follow those that
+// made it. Entries level on score are split by playoff
+// place, then by countback when every tied entry has finished; entries still
+// level share a position.
+func rankPositions(entries []rankedEntry, direction SortDirection) ([]int, []int, []bool) {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if a.MissedCut != b.MissedCut {
+			return b.MissedCut
+		}
+		if a.Score != b.Score {
+			return direction.Better(a.Score, b.Score)
+		}
+		if a.Thru != b.Thru {
+			return a.Thru > b.Thru
+		}
+		return a.Name < b.Name
+	})
+
+	positions := make([]int, len(entries))
+	shared := make([]bool, len(entries))
+
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && entries[order[end]].Score == entries[order[start]].Score &&
+			entries[order[end]].MissedCut == entries[order[start]].MissedCut {
+			end++
+		}
+		group := order[start:end]
+
+		useCountback := true
+		for _, idx := range group {
+			if entries[idx].Countback == nil {
+				useCountback = false
+			}
+		}
+
+		compare := func(a, b rankedEntry) int {
+			if c := comparePlayoff(a.Playoff, b.Playoff); c != 0 {
+				return c
+			}
+			if useCountback {
+				for k := range a.Countback {
+					if k >= len(b.Countback) || a.Countback[k] == b.Countback[k] {
+						continue
+					}
+					if direction.Better(a.Countback[k], b.Countback[k]) {
+						return -1
+					}
+					return 1
+				}
+			}
+			return 0
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return compare(entries[group[i]], entries[group[j]]) < 0
+		})
+
+		for i, idx := range group {
+			first := i
+			for first > 0 && compare(entries[group[first-1]], entries[idx]) == 0 {
+				first--
+			}
+			positions[idx] = start + first + 1
+			shared[idx] = (i > 0 && compare(entries[group[i-1]], entries[idx]) == 0) ||
+				(i+1 < len(group) && compare(entries[group[i+1]], entries[idx]) == 0)
+		}
+
+		start = end
+	}
+
+	return order, positions, shared
+}
+
+// comparePlayoff orders playoff places; teams that won a place finish ahead
+// of tied teams that did not play off
+func comparePlayoff(a, b int) int {
+	switch {
+	case a == b:
+		return 0
+	case a == 0:
+		return 1
+	case b == 0:
+		return -1
+	case a < b:
+		return -1
+	}
+	return 1
+}
+
+// positionLabel formats a position, prefixing shared positions with "T"
+func positionLabel(position int, shared bool) string {
+	if shared {
+		return fmt.Sprintf("T%d", position)
+	}
+	return fmt.Sprintf("%d", position)
+}
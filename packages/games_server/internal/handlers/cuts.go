@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// SetTournamentCut changes a tournament's cut. A null body removes it. When
+// the cut round is already complete the cut is made again under the new rule.
+func SetTournamentCut(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		var req *models.CutRule
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		t, err := db.GetTournament(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if t == nil {
+			http.Error(w, "Tournament not found", http.StatusNotFound)
+			return
+		}
+
+		rounds, err := db.GetTournamentRounds(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The cut needs a later round for the teams that make it
+		if req != nil {
+			cutRound, laterRound := false, false
+			for _, round := range rounds {
+				cutRound = cutRound || round.RoundNumber == req.AfterRound
+				laterRound = laterRound || round.RoundNumber > req.AfterRound
+			}
+			if !cutRound || !laterRound {
+				http.Error(w, fmt.Sprintf("Cut after round %d must be followed by another round", req.AfterRound), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if err := db.SetTournamentCutRule(tournamentID, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := db.ClearMissedCuts(tournamentID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if req != nil {
+			for _, round := range rounds {
+				if round.RoundNumber == req.AfterRound && round.Status == "completed" {
+					if _, err := game.ApplyCut(r.Context(), db, cache, tournamentID); err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+						return
+					}
+				}
+			}
+		}
+		cache.InvalidateLeaderboard(tournamentID)
+		leaderboards.ScoreChanged(db, cache, tournamentID)
+
+		t.Cut = req
+		json.NewEncoder(w).Encode(t)
+	}
+}
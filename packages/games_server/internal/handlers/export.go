@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// -- Exports --
+
+const (
+	exportJSON = "json"
+	exportCSV  = "csv"
+)
+
+// negotiateExport picks the export format from the format query parameter,
+// or else the first acceptable media type of the Accept header. JSON may ask
+// for a feed version, e.g. "application/json; version=1". It returns "" when
+// nothing acceptable can be produced.
+func negotiateExport(r *http.Request) string {
+	switch r.URL.Query().Get("format") {
+	case exportCSV:
+		return exportCSV
+	case exportJSON:
+		return exportJSON
+	case "":
+	default:
+		return ""
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return exportJSON
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return exportCSV
+		case "application/json", "application/*", "*/*":
+			if v, ok := params["version"]; ok && v != strconv.Itoa(game.ExportVersion) {
+				continue
+			}
+			return exportJSON
+		}
+	}
+	return ""
+}
+
+// writeExport encodes an export in the negotiated format. writeCSV writes
+// the CSV rendering.
+func writeExport(w http.ResponseWriter, r *http.Request, filename string, feed interface{}, writeCSV func(*csv.Writer)) {
+	w.Header().Set("Vary", "Accept")
+
+	switch negotiateExport(r) {
+	case exportCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".csv"))
+		cw := csv.NewWriter(w)
+		writeCSV(cw)
+		cw.Flush()
+	case exportJSON:
+		w.Header().Set("Content-Type", fmt.Sprintf("application/json; version=%d", game.ExportVersion))
+		json.NewEncoder(w).Encode(feed)
+	default:
+		http.Error(w, "Export is available as text/csv or application/json", http.StatusNotAcceptable)
+	}
+}
+
+// ExportLeaderboard exports a tournament's leaderboard as CSV or JSON
+func ExportLeaderboard(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		export, err := game.ExportLeaderboard(r.Context(), db, cache, tournamentID, 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("tournament-%d-leaderboard", tournamentID)
+		writeExport(w, r, filename, export, func(cw *csv.Writer) {
+			writeLeaderboardCSV(cw, export)
+		})
+	}
+}
+
+// ExportRoundLeaderboard exports a single round's leaderboard as CSV or JSON
+func ExportRoundLeaderboard(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil || round.TournamentID != tournamentID {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		export, err := game.ExportLeaderboard(r.Context(), db, cache, tournamentID, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("tournament-%d-round-%d-leaderboard", tournamentID, round.RoundNumber)
+		writeExport(w, r, filename, export, func(cw *csv.Writer) {
+			writeLeaderboardCSV(cw, export)
+		})
+	}
+}
+
+// ExportRoundScorecards exports every team's hole-by-hole scorecard for a
+// round as CSV or JSON
+func ExportRoundScorecards(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		export, err := game.ExportScorecards(r.Context(), db, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("tournament-%d-round-%d-scorecards", round.TournamentID, round.RoundNumber)
+		writeExport(w, r, filename, export, func(cw *csv.Writer) {
+			writeScorecardsCSV(cw, export)
+		})
+	}
+}
+
+// writeLeaderboardCSV writes a leaderboard in the columns of the results
+// spreadsheet: position, team, players, handicaps, R1..Rn, total and to-par
+func writeLeaderboardCSV(cw *csv.Writer, export *game.LeaderboardExport) {
+	header := []string{"Position", "Team", "Players", "Handicaps"}
+	for _, r := range export.Rounds {
+		header = append(header, fmt.Sprintf("R%d", r.RoundNumber))
+	}
+	header = append(header, "Total", "To Par")
+	cw.Write(header)
+
+	for _, row := range export.Rows {
+		position := row.Position
+		if row.MissedCut {
+			position = "CUT"
+		}
+
+		handicaps := make([]string, len(row.Handicaps))
+		for i, h := range row.Handicaps {
+			handicaps[i] = strconv.FormatFloat(h, 'f', 1, 64)
+		}
+
+		record := []string{position, row.Team, strings.Join(row.Players, " / "), strings.Join(handicaps, " / ")}
+		for _, v := range row.Rounds {
+			record = append(record, optionalInt(v))
+		}
+		toPar := ""
+		if row.ToPar != nil {
+			toPar = formatToPar(*row.ToPar)
+		}
+		record = append(record, strconv.Itoa(row.Total), toPar)
+		cw.Write(record)
+	}
+}
+
+// writeScorecardsCSV writes one line per ball for every team, with each hole
+// a column, followed by the team's counting score
+func writeScorecardsCSV(cw *csv.Writer, export *game.ScorecardExport) {
+	for i, card := range export.Scorecards {
+		eighteen := card.Front != nil && card.Back != nil
+
+		if i == 0 {
+			header := []string{"Team", "Player", "Handicap"}
+			for _, h := range card.Holes {
+				header = append(header, strconv.Itoa(h.HoleNumber))
+			}
+			if eighteen {
+				header = append(header, "Out", "In")
+			}
+			header = append(header, "Total")
+			cw.Write(header)
+		}
+
+		// Players by their own ball, and the team ball, if any
+		type ballRow struct {
+			name     string
+			handicap string
+			holes    []*int
+		}
+		rows := []*ballRow{}
+		byPlayer := make(map[int]*ballRow)
+		for _, p := range card.Players {
+			row := &ballRow{name: p.Name, handicap: strconv.Itoa(p.CourseHandicap), holes: make([]*int, len(card.Holes))}
+			byPlayer[p.PlayerID] = row
+			rows = append(rows, row)
+		}
+		var teamBall *ballRow
+		for h, hole := range card.Holes {
+			for _, e := range hole.Entries {
+				gross := e.Gross
+				if e.PlayerID == nil {
+					if teamBall == nil {
+						teamBall = &ballRow{name: card.Name, holes: make([]*int, len(card.Holes))}
+						if card.TeamHandicap != nil {
+							teamBall.handicap = strconv.Itoa(*card.TeamHandicap)
+						}
+					}
+					teamBall.holes[h] = &gross
+				} else if row, ok := byPlayer[*e.PlayerID]; ok {
+					row.holes[h] = &gross
+				}
+			}
+		}
+		if teamBall != nil {
+			rows = append(rows, teamBall)
+		}
+
+		for _, row := range rows {
+			record := []string{card.Name, row.name, row.handicap}
+			out, in, total := 0, 0, 0
+			for h, v := range row.holes {
+				record = append(record, optionalInt(v))
+				if v == nil {
+					continue
+				}
+				total += *v
+				if card.Holes[h].HoleNumber <= game.RegulationHoles/2 {
+					out += *v
+				} else {
+					in += *v
+				}
+			}
+			if eighteen {
+				record = append(record, strconv.Itoa(out), strconv.Itoa(in))
+			}
+			cw.Write(append(record, strconv.Itoa(total)))
+		}
+
+		// The counting score: to par, or points
+		record := []string{card.Name, "Score", ""}
+		for _, h := range card.Holes {
+			record = append(record, optionalInt(h.Score))
+		}
+		if eighteen {
+			record = append(record, strconv.Itoa(card.Front.Score), strconv.Itoa(card.Back.Score))
+		}
+		cw.Write(append(record, strconv.Itoa(card.Total.Score)))
+	}
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+// formatToPar renders a score to par as on a results sheet: "E", "+3", "-2"
+func formatToPar(v int) string {
+	switch {
+	case v == 0:
+		return "E"
+	case v > 0:
+		return "+" + strconv.Itoa(v)
+	default:
+		return strconv.Itoa(v)
+	}
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// GetRoundHandicaps returns the course and playing handicap of every player in a round
+func GetRoundHandicaps(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		handicaps, err := game.CalculateRoundHandicaps(r.Context(), db, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(handicaps)
+	}
+}
+
+// GetRoundTeamHandicaps returns the handicap each team plays off in a round
+func GetRoundTeamHandicaps(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		handicaps, err := game.CalculateTeamHandicaps(r.Context(), db, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(handicaps)
+	}
+}
+
+func GetCourseTees(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid course ID", http.StatusBadRequest)
+			return
+		}
+
+		tees, err := db.GetCourseTees(courseID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(tees)
+	}
+}
+
+// UpdateCourseTeeRating sets the slope, course rating and par of a tee set
+func UpdateCourseTeeRating(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		courseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid course ID", http.StatusBadRequest)
+			return
+		}
+		teeID, err := strconv.Atoi(chi.URLParam(r, "teeId"))
+		if err != nil {
+			http.Error(w, "Invalid tee ID", http.StatusBadRequest)
+			return
+		}
+
+		var req models.UpdateTeeRatingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// WHS slope ratings range from 55 to 155
+		if req.Slope == 0 {
+			req.Slope = game.StandardSlope
+		}
+		if req.Slope < 55 || req.Slope > 155 {
+			http.Error(w, "Slope must be between 55 and 155", http.StatusBadRequest)
+			return
+		}
+
+		tee, err := db.UpdateCourseTeeRating(courseID, teeID, req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if tee == nil {
+			http.Error(w, "Tee not found", http.StatusNotFound)
+			return
+		}
+
+		// Course handicaps follow the ratings, so rescore the course's rounds
+		rounds, err := db.GetCourseRounds(courseID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, round := range rounds {
+			if _, err := game.RebuildRoundResults(r.Context(), db, round.ID); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			cache.InvalidateRoundStats(round.ID)
+			cache.InvalidateLeaderboard(round.TournamentID)
+		}
+
+		json.NewEncoder(w).Encode(tee)
+	}
+}
+
+// GetRoundAdjustedScores returns each player's raw and adjusted gross for a round
+func GetRoundAdjustedScores(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		scores, err := game.CalculateAdjustedScores(r.Context(), db, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(scores)
+	}
+}
+
+// SetTournamentMaxScore sets or clears the max hole score of a tournament
+func SetTournamentMaxScore(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		var req *models.MaxScore
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		t, err := db.GetTournament(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if t == nil {
+			http.Error(w, "Tournament not found", http.StatusNotFound)
+			return
+		}
+
+		if err := db.SetTournamentMaxScore(tournamentID, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Team hole results and cached round totals depend on the cap
+		if err := game.RebuildTournamentResults(r.Context(), db, tournamentID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rounds, err := db.GetTournamentRounds(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, round := range rounds {
+			cache.InvalidateRoundStats(round.ID)
+		}
+		cache.InvalidateLeaderboard(tournamentID)
+
+		t.MaxScore = req
+		json.NewEncoder(w).Encode(t)
+	}
+}
+
+// PostRoundDifferentials posts score differentials for a completed round
+func PostRoundDifferentials(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		var req models.PostDifferentialsRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+		if round.Status != "completed" {
+			http.Error(w, "Round is not completed", http.StatusConflict)
+			return
+		}
+
+		diffs, err := game.PostRoundDifferentials(r.Context(), db, roundID, req.UpdateHandicaps)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Handicaps feed net scoring of the rounds not yet begun, so the
+		// leaderboard and cached round totals change with them
+		if req.UpdateHandicaps {
+			if err := game.RebuildTournamentResults(r.Context(), db, round.TournamentID); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			rounds, err := db.GetTournamentRounds(round.TournamentID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			for _, rd := range rounds {
+				cache.InvalidateRoundStats(rd.ID)
+			}
+			cache.InvalidateLeaderboard(round.TournamentID)
+		}
+
+		json.NewEncoder(w).Encode(diffs)
+	}
+}
+
+// GetPlayerDifferentials returns a player's differential history and index
+func GetPlayerDifferentials(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		playerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid player ID", http.StatusBadRequest)
+			return
+		}
+
+		history, err := game.GetHandicapHistory(r.Context(), db, playerID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if history == nil {
+			http.Error(w, "Player not found", http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(history)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
 	"github.com/patrick-salvatore/games-server/internal/infra"
 	"github.com/patrick-salvatore/games-server/internal/models"
 	"github.com/patrick-salvatore/games-server/internal/store"
@@ -101,6 +103,10 @@ func SubmitScore(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
 			}
 
 			newScore, err := db.SubmitScore(score)
+			if errors.Is(err, store.ErrMissedCut) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
@@ -110,6 +116,7 @@ func SubmitScore(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
 				rID := *newScore.TournamentRoundID
 				if !invalidatedRounds[rID] {
 					cache.InvalidateRoundStats(rID)
+					publishRoundScore(db, cache, rID)
 					invalidatedRounds[rID] = true
 				}
 			}
@@ -552,14 +559,83 @@ func SubmitRoundScore(db *store.Store, cache *infra.CacheManager) http.HandlerFu
 		}
 
 		_, err = db.SubmitRoundScore(roundID, req)
+		if errors.Is(err, store.ErrMissedCut) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
 		cache.InvalidateRoundStats(roundID)
+		publishRoundScore(db, cache, roundID)
 
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(map[string]string{"status": "success"})
 	}
 }
+
+// UpdateRoundStatus moves a round between pending, active and completed.
+// Completing a tournament's cut round makes the cut; reopening it lifts it.
+func UpdateRoundStatus(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		var req models.UpdateRoundStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch req.Status {
+		case "pending", "active", "completed":
+		default:
+			http.Error(w, "Status must be pending, active or completed", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		if err := db.UpdateTournamentRoundStatus(roundID, req.Status); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// Completed rounds are cached; any other status is recomputed live
+		cache.InvalidateRoundStats(roundID)
+
+		t, err := db.GetTournament(round.TournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if t != nil && t.Cut != nil && t.Cut.AfterRound == round.RoundNumber {
+			if req.Status == "completed" {
+				_, err = game.ApplyCut(r.Context(), db, cache, t.ID)
+			} else {
+				err = db.ClearMissedCuts(t.ID)
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		cache.InvalidateLeaderboard(round.TournamentID)
+		publishRoundScore(db, cache, roundID)
+
+		round.Status = req.Status
+		json.NewEncoder(w).Encode(round)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -27,6 +28,10 @@ func GetLeaderboard(db *store.Store, cache *infra.CacheManager) http.HandlerFunc
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		if err := game.ProjectLeaderboard(r.Context(), db, leaderboard); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
 		json.NewEncoder(w).Encode(leaderboard)
 	}
@@ -51,6 +56,10 @@ func SubmitTeamScore(db *store.Store, cache *infra.CacheManager) http.HandlerFun
 		req.PlayerID = nil
 
 		score, err := db.SubmitScore(req)
+		if errors.Is(err, store.ErrMissedCut) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -58,6 +67,7 @@ func SubmitTeamScore(db *store.Store, cache *infra.CacheManager) http.HandlerFun
 
 		if score.TournamentRoundID != nil {
 			cache.InvalidateRoundStats(*score.TournamentRoundID)
+			publishRoundScore(db, cache, *score.TournamentRoundID)
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -65,7 +75,7 @@ func SubmitTeamScore(db *store.Store, cache *infra.CacheManager) http.HandlerFun
 	}
 }
 
-// GetRoundLeaderboard calculates and returns the leaderboard for a specific tournament round
+// GetRoundLeaderboard calculates and returns the leaderboard for a single tournament round
 func GetRoundLeaderboard(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tournamentIDParam := chi.URLParam(r, "id")
@@ -85,7 +95,7 @@ func GetRoundLeaderboard(db *store.Store, cache *infra.CacheManager) http.Handle
 
 		// Validate that round belongs to tournament
 		round, err := db.GetTournamentRound(roundID)
-		if err != nil {
+		if err != nil || round == nil {
 			http.Error(w, "Round not found", http.StatusNotFound)
 			return
 		}
@@ -94,11 +104,15 @@ func GetRoundLeaderboard(db *store.Store, cache *infra.CacheManager) http.Handle
 			return
 		}
 
-		leaderboard, err := game.CalculateLeaderboard(r.Context(), db, cache, tournamentID)
+		leaderboard, err := game.CalculateRoundLeaderboard(r.Context(), db, cache, tournamentID, roundID)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		if err := game.ProjectLeaderboard(r.Context(), db, leaderboard); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
 		json.NewEncoder(w).Encode(leaderboard)
 	}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// -- Leaderboard Hub --
+
+// LeaderboardHub recomputes a tournament's leaderboard once per score and
+// pushes what changed to the tournament's event stream subscribers.
+type LeaderboardHub struct {
+	mu       sync.Mutex
+	clients  map[int]map[chan game.LeaderboardDelta]bool // tournament -> set of channels
+	boards   map[int]*game.LeaderboardResponse           // last board pushed per tournament
+	versions map[int]int64
+	running  map[int]bool // a recompute is in flight
+	dirty    map[int]bool // scores landed during the recompute in flight
+}
+
+var leaderboards = &LeaderboardHub{
+	clients:  make(map[int]map[chan game.LeaderboardDelta]bool),
+	boards:   make(map[int]*game.LeaderboardResponse),
+	versions: make(map[int]int64),
+	running:  make(map[int]bool),
+	dirty:    make(map[int]bool),
+}
+
+func (h *LeaderboardHub) Subscribe(tournamentID int) chan game.LeaderboardDelta {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan game.LeaderboardDelta, 10)
+	if _, ok := h.clients[tournamentID]; !ok {
+		h.clients[tournamentID] = make(map[chan game.LeaderboardDelta]bool)
+	}
+	h.clients[tournamentID][ch] = true
+	return ch
+}
+
+func (h *LeaderboardHub) Unsubscribe(tournamentID int, ch chan game.LeaderboardDelta) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if clients, ok := h.clients[tournamentID]; ok {
+		delete(clients, ch)
+		close(ch)
+		if len(clients) == 0 {
+			delete(h.clients, tournamentID)
+			// Nobody is watching; the next subscriber starts from a fresh board
+			delete(h.boards, tournamentID)
+		}
+	}
+}
+
+// Snapshot returns the full current leaderboard for a new subscriber
+func (h *LeaderboardHub) Snapshot(ctx context.Context, db *store.Store, cache *infra.CacheManager, tournamentID int) (game.LeaderboardDelta, error) {
+	h.mu.Lock()
+	board, version := h.boards[tournamentID], h.versions[tournamentID]
+	h.mu.Unlock()
+
+	if board == nil {
+		lb, err := game.CalculateLeaderboard(ctx, db, cache, tournamentID)
+		if err != nil {
+			return game.LeaderboardDelta{}, err
+		}
+		h.mu.Lock()
+		if h.boards[tournamentID] == nil {
+			h.boards[tournamentID] = lb
+		}
+		board, version = h.boards[tournamentID], h.versions[tournamentID]
+		h.mu.Unlock()
+	}
+
+	delta := game.FullLeaderboardDelta(board)
+	delta.Version = version
+	return delta, nil
+}
+
+// ScoreChanged recomputes a tournament's leaderboard in the background and
+// pushes the changes. Scores landing while a recompute is in flight are
+// picked up by a single follow-up recompute.
+func (h *LeaderboardHub) ScoreChanged(db *store.Store, cache *infra.CacheManager, tournamentID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.clients[tournamentID]) == 0 {
+		delete(h.boards, tournamentID)
+		return
+	}
+	if h.running[tournamentID] {
+		h.dirty[tournamentID] = true
+		return
+	}
+	h.running[tournamentID] = true
+
+	go h.recompute(db, cache, tournamentID)
+}
+
+func (h *LeaderboardHub) recompute(db *store.Store, cache *infra.CacheManager, tournamentID int) {
+	for {
+		lb, err := game.CalculateLeaderboard(context.Background(), db, cache, tournamentID)
+
+		h.mu.Lock()
+		if err != nil {
+			log.Printf("leaderboard stream: tournament %d: %v", tournamentID, err)
+		} else if len(h.clients[tournamentID]) > 0 {
+			delta := game.DiffLeaderboard(h.boards[tournamentID], lb)
+			h.boards[tournamentID] = lb
+			if !delta.Empty() {
+				h.versions[tournamentID]++
+				delta.Version = h.versions[tournamentID]
+				h.broadcast(tournamentID, delta)
+			}
+		}
+
+		if h.dirty[tournamentID] {
+			delete(h.dirty, tournamentID)
+			h.mu.Unlock()
+			continue
+		}
+		delete(h.running, tournamentID)
+		h.mu.Unlock()
+		return
+	}
+}
+
+// broadcast must be called with the lock held
+func (h *LeaderboardHub) broadcast(tournamentID int, delta game.LeaderboardDelta) {
+	for ch := range h.clients[tournamentID] {
+		select {
+		case ch <- delta:
+		default:
+			// Client too slow, drop message (it can re-fetch the leaderboard)
+		}
+	}
+}
+
+// publishRoundScore tells leaderboard and sync subscribers that a score landed
+// in a round. The triggers logged it under the tournament's namespace.
+func publishRoundScore(db *store.Store, cache *infra.CacheManager, roundID int) {
+	round, err := db.GetTournamentRound(roundID)
+	if err != nil || round == nil {
+		return
+	}
+	leaderboards.ScoreChanged(db, cache, round.TournamentID)
+	broadcaster.Broadcast(round.TournamentID, currentVersion(db))
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// GetRoundMatches returns the live status of every match in a match play round
+func GetRoundMatches(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+		if !round.IsMatchPlay {
+			http.Error(w, "Round is not match play", http.StatusBadRequest)
+			return
+		}
+
+		matches, err := game.CalculateRoundMatches(r.Context(), db, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(matches)
+	}
+}
+
+// SetRoundMatches replaces the pairings for a match play round
+func SetRoundMatches(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		var req models.SetRoundMatchesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+		if !round.IsMatchPlay {
+			http.Error(w, "Round is not match play", http.StatusBadRequest)
+			return
+		}
+
+		reason, err := validateRoundMatches(db, round.TournamentID, req.Matches)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if reason != "" {
+			http.Error(w, reason, http.StatusBadRequest)
+			return
+		}
+
+		matches, err := db.SetRoundMatchesTx(roundID, req.Matches)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		cache.InvalidateLeaderboard(round.TournamentID)
+
+		json.NewEncoder(w).Encode(matches)
+	}
+}
+
+// validateRoundMatches checks each pairing is between two of the
+// tournament's teams and that singles players are on the team they play
+// for. It returns why a pairing is invalid, or "".
+func validateRoundMatches(db *store.Store, tournamentID int, matches []models.RoundMatch) (string, error) {
+	teams, err := db.GetTeamsByTournament(tournamentID)
+	if err != nil {
+		return "", err
+	}
+	inTournament := make(map[int]bool)
+	for _, t := range teams {
+		inTournament[t.ID] = true
+	}
+
+	players, err := db.GetTournamentPlayers(tournamentID)
+	if err != nil {
+		return "", err
+	}
+	playerTeam := make(map[int]int)
+	for _, p := range players {
+		playerTeam[p.ID] = p.TeamID
+	}
+
+	for i, m := range matches {
+		if !inTournament[m.SideATeamID] || !inTournament[m.SideBTeamID] {
+			return fmt.Sprintf("match %d: both teams must be in the round's tournament", i+1), nil
+		}
+		if m.SideATeamID == m.SideBTeamID {
+			return fmt.Sprintf("match %d: a team cannot play itself", i+1), nil
+		}
+		if m.SideAPlayerID != nil && playerTeam[*m.SideAPlayerID] != m.SideATeamID {
+			return fmt.Sprintf("match %d: player %d is not on team %d", i+1, *m.SideAPlayerID, m.SideATeamID), nil
+		}
+		if m.SideBPlayerID != nil && playerTeam[*m.SideBPlayerID] != m.SideBTeamID {
+			return fmt.Sprintf("match %d: player %d is not on team %d", i+1, *m.SideBPlayerID, m.SideBTeamID), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// -- Replay --
+
+// replayVersion resolves the changelog version a replay is taken at, from
+// ?version= or ?at= (RFC 3339 or unix milliseconds). It writes the error
+// response and returns false when there is none.
+func replayVersion(w http.ResponseWriter, r *http.Request, db *store.Store, tournamentID int) (int64, bool) {
+	q := r.URL.Query()
+	if v := q.Get("version"); v != "" {
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || version < 0 {
+			http.Error(w, "Invalid version", http.StatusBadRequest)
+			return 0, false
+		}
+		return version, true
+	}
+
+	v := q.Get("at")
+	if v == "" {
+		http.Error(w, "version or at is required", http.StatusBadRequest)
+		return 0, false
+	}
+	at, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid at: use RFC 3339 or unix milliseconds", http.StatusBadRequest)
+			return 0, false
+		}
+		at = time.UnixMilli(ms)
+	}
+	version, err := db.GetChangelogVersionAt(tournamentID, at)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return 0, false
+	}
+	return version, true
+}
+
+// ReplayLeaderboard rebuilds a tournament's leaderboard as it stood at a
+// changelog version or time. History before the compaction horizon is gone.
+func ReplayLeaderboard(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		version, ok := replayVersion(w, r, db, tournamentID)
+		if !ok {
+			return
+		}
+
+		replay, err := game.ReplayLeaderboard(r.Context(), db, tournamentID, 0, version)
+		if errors.Is(err, store.ErrCompacted) {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(replay)
+	}
+}
+
+// ReplayRoundLeaderboard rebuilds a single round's leaderboard as it stood at
+// a changelog version or time
+func ReplayRoundLeaderboard(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil || round.TournamentID != tournamentID {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		version, ok := replayVersion(w, r, db, tournamentID)
+		if !ok {
+			return
+		}
+
+		replay, err := game.ReplayLeaderboard(r.Context(), db, tournamentID, roundID, version)
+		if errors.Is(err, store.ErrCompacted) {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(replay)
+	}
+}
+
+// GetLeaderboardTimeline lists every change of leader over a tournament
+func GetLeaderboardTimeline(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		timeline, err := game.LeaderboardTimeline(r.Context(), db, tournamentID, 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(timeline)
+	}
+}
+
+// GetRoundLeaderboardTimeline lists every change of leader over a round
+func GetRoundLeaderboardTimeline(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil || round.TournamentID != tournamentID {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		timeline, err := game.LeaderboardTimeline(r.Context(), db, tournamentID, roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(timeline)
+	}
+}
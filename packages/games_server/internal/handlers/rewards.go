@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// GetRewardMetrics returns the catalogue of metrics rewards can be decided on
+func GetRewardMetrics(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(game.RewardMetrics)
+}
+
+// GetTournamentRewards returns the current leaders, and the winners once the
+// tournament is complete, of every reward of a tournament
+func GetTournamentRewards(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		rewards, err := game.CalculateRewards(r.Context(), db, cache, tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(rewards)
+	}
+}
+
+// CreateTournamentReward adds a reward to a tournament, decided on one of the
+// catalogue's metrics
+func CreateTournamentReward(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		var req models.CreateRewardRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		metric := game.LookupRewardMetric(req.Metric)
+		if metric == nil {
+			http.Error(w, "Unknown reward metric", http.StatusBadRequest)
+			return
+		}
+		if !metric.Supports(req.Scope) {
+			http.Error(w, "Metric "+metric.Code+" cannot be awarded to a "+req.Scope, http.StatusBadRequest)
+			return
+		}
+
+		t, err := db.GetTournament(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if t == nil {
+			http.Error(w, "Tournament not found", http.StatusNotFound)
+			return
+		}
+
+		description := req.Description
+		if description == "" {
+			description = metric.Name
+		}
+		reward, err := db.CreateTournamentReward(tournamentID, req.Scope, req.Metric, description)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(reward)
+	}
+}
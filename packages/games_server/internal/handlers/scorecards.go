@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// GetRoundScorecard returns the hole-by-hole scorecard of a team or player in a round.
+// Exactly one of the teamId or playerId query params selects the card.
+func GetRoundScorecard(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		playerIDStr := r.URL.Query().Get("playerId")
+		teamIDStr := r.URL.Query().Get("teamId")
+		if (playerIDStr == "") == (teamIDStr == "") {
+			http.Error(w, "One of teamId or playerId is required", http.StatusBadRequest)
+			return
+		}
+
+		var playerID, teamID *int
+		if playerIDStr != "" {
+			id, err := strconv.Atoi(playerIDStr)
+			if err != nil {
+				http.Error(w, "Invalid player ID", http.StatusBadRequest)
+				return
+			}
+			playerID = &id
+		} else {
+			id, err := strconv.Atoi(teamIDStr)
+			if err != nil {
+				http.Error(w, "Invalid team ID", http.StatusBadRequest)
+				return
+			}
+			teamID = &id
+		}
+
+		round, err := db.GetTournamentRound(roundID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if round == nil {
+			http.Error(w, "Round not found", http.StatusNotFound)
+			return
+		}
+
+		card, err := game.BuildScorecard(r.Context(), db, roundID, teamID, playerID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if card == nil {
+			http.Error(w, "Team or player not found in this tournament", http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(card)
+	}
+}
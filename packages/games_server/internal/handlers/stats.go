@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// tournamentStats computes the stats of the tournament in the URL, writing
+// the error response itself when it fails
+func tournamentStats(db *store.Store, w http.ResponseWriter, r *http.Request) *game.TournamentStats {
+	tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+		return nil
+	}
+
+	t, err := db.GetTournament(tournamentID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+	if t == nil {
+		http.Error(w, "Tournament not found", http.StatusNotFound)
+		return nil
+	}
+
+	stats, err := game.CalculateTournamentStats(r.Context(), db, tournamentID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+	return stats
+}
+
+// GetTournamentStats returns every scoring statistic of a tournament
+func GetTournamentStats(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if stats := tournamentStats(db, w, r); stats != nil {
+			json.NewEncoder(w).Encode(stats)
+		}
+	}
+}
+
+// GetPlayerStats returns each player's score counts
+func GetPlayerStats(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if stats := tournamentStats(db, w, r); stats != nil {
+			json.NewEncoder(w).Encode(stats.Players)
+		}
+	}
+}
+
+// GetTeamStats returns each team's score counts
+func GetTeamStats(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if stats := tournamentStats(db, w, r); stats != nil {
+			json.NewEncoder(w).Encode(stats.Teams)
+		}
+	}
+}
+
+// GetRoundStats returns a round's score counts with the field average and
+// difficulty ranking of each hole
+func GetRoundStats(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roundID, err := strconv.Atoi(chi.URLParam(r, "roundId"))
+		if err != nil {
+			http.Error(w, "Invalid round ID", http.StatusBadRequest)
+			return
+		}
+
+		stats := tournamentStats(db, w, r)
+		if stats == nil {
+			return
+		}
+		for _, rs := range stats.Rounds {
+			if rs.RoundID == roundID {
+				json.NewEncoder(w).Encode(rs)
+				return
+			}
+		}
+		http.Error(w, "Round not found", http.StatusNotFound)
+	}
+}
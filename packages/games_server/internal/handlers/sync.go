@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/patrick-salvatore/games-server/internal/game"
+	"github.com/patrick-salvatore/games-server/internal/infra"
 	"github.com/patrick-salvatore/games-server/internal/middleware"
 	"github.com/patrick-salvatore/games-server/internal/models"
 	"github.com/patrick-salvatore/games-server/internal/store"
@@ -25,27 +28,33 @@ var broadcaster = &Broadcaster{
 	clients: make(map[int]map[chan int64]bool),
 }
 
-func (b *Broadcaster) Subscribe(namespace int) chan int64 {
+// Subscribe returns a channel that receives the new version whenever any of
+// the namespaces changes
+func (b *Broadcaster) Subscribe(namespaces ...int) chan int64 {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	ch := make(chan int64, 10) // buffer to hold a few updates
-	if _, ok := b.clients[namespace]; !ok {
-		b.clients[namespace] = make(map[chan int64]bool)
+	for _, namespace := range namespaces {
+		if _, ok := b.clients[namespace]; !ok {
+			b.clients[namespace] = make(map[chan int64]bool)
+		}
+		b.clients[namespace][ch] = true
 	}
-	b.clients[namespace][ch] = true
 	return ch
 }
 
-func (b *Broadcaster) Unsubscribe(namespace int, ch chan int64) {
+func (b *Broadcaster) Unsubscribe(ch chan int64, namespaces ...int) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	if clients, ok := b.clients[namespace]; ok {
-		delete(clients, ch)
-		close(ch)
-		if len(clients) == 0 {
-			delete(b.clients, namespace)
+	for _, namespace := range namespaces {
+		if clients, ok := b.clients[namespace]; ok {
+			delete(clients, ch)
+			if len(clients) == 0 {
+				delete(b.clients, namespace)
+			}
 		}
 	}
+	close(ch)
 }
 
 func (b *Broadcaster) Broadcast(namespace int, version int64) {
@@ -62,22 +71,87 @@ func (b *Broadcaster) Broadcast(namespace int, version int64) {
 	}
 }
 
-// -- Helpers --
+// -- Namespaces --
+
+// syncSession holds the namespaces a token may sync. The shared namespace is
+// the tournament's ID, as the domain triggers write it. A player's private
+// namespace, for drafts, is the negated player ID so it never collides with
+// a tournament's.
+type syncSession struct {
+	tournament int
+	player     int
+	private    int // 0 when the token carries no player
+	team       int
+	admin      bool
+}
+
+func privateNamespace(playerID int) int {
+	return -playerID
+}
+
+// getSession authorizes a sync session from the token's tournament claim
+func getSession(r *http.Request) (syncSession, error) {
+	tournamentID, ok := r.Context().Value(middleware.TournamentIDKey).(int)
+	if !ok || tournamentID == 0 {
+		return syncSession{}, fmt.Errorf("tournamentId not found in token")
+	}
+
+	session := syncSession{tournament: tournamentID}
+	session.team, _ = r.Context().Value(middleware.TeamIDKey).(int)
+	session.admin, _ = r.Context().Value(middleware.IsAdminKey).(bool)
+	if playerID, ok := r.Context().Value(middleware.PlayerIDKey).(int); ok && playerID != 0 {
+		session.player = playerID
+		session.private = privateNamespace(playerID)
+	}
+	return session, nil
+}
+
+// namespaces lists the namespaces a read covers: the tournament's, plus the
+// player's private namespace when asked for with ?private=true
+func (s syncSession) namespaces(r *http.Request) []int {
+	namespaces := []int{s.tournament}
+	if private, _ := strconv.ParseBool(r.URL.Query().Get("private")); private && s.private != 0 {
+		namespaces = append(namespaces, s.private)
+	}
+	return namespaces
+}
 
-func getNamespace(r *http.Request) (int, error) {
-	playerID, ok := r.Context().Value(middleware.PlayerIDKey).(int)
-	if !ok || playerID == 0 {
-		return 0, fmt.Errorf("namespace (playerId or tournamentId) not found in context")
+// namespaceFor resolves the namespace a mutation's scope writes to
+func (s syncSession) namespaceFor(scope string) (int, error) {
+	switch scope {
+	case "", models.SyncScopeTournament:
+		return s.tournament, nil
+	case models.SyncScopePrivate:
+		if s.private == 0 {
+			return 0, fmt.Errorf("private scope requires a player token")
+		}
+		return s.private, nil
+	default:
+		return 0, fmt.Errorf("unknown scope %q", scope)
 	}
+}
 
-	return playerID, nil
+// currentVersion reads the global changelog version
+func currentVersion(db *store.Store) int64 {
+	var version int64
+	_ = db.DB.QueryRow("SELECT value FROM meta WHERE key='version'").Scan(&version)
+	return version
 }
 
 // -- Handlers --
 
-func Mutate(db *store.Store) http.HandlerFunc {
+// MutationDedupeWindow is how long a client's mutation results are kept to
+// answer retries
+const MutationDedupeWindow = 24 * time.Hour
+
+// Mutate applies a batch of mutations, each on its own, and reports each
+// one's result in request order. Registered entity types are written through
+// their domain tables; other types are drafts kept in the player's private
+// namespace. A mutation the client has sent before returns its original
+// result, and sequenced mutations apply strictly in order.
+func Mutate(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		namespace, err := getNamespace(r)
+		session, err := getSession(r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -89,122 +163,276 @@ func Mutate(db *store.Store) http.HandlerFunc {
 			return
 		}
 
-		tx, err := db.DB.Begin()
-		if err != nil {
-			http.Error(w, "Failed to start transaction", http.StatusInternalServerError)
-			return
+		client := store.SyncClient{TournamentID: session.tournament, PlayerID: session.player, ClientID: req.ClientID}
+		var lastSeq int64
+		if req.ClientID != "" {
+			if lastSeq, err = db.GetClientSeq(client); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
 		}
-		defer tx.Rollback()
-
-		// Set Transaction Context
-		// Note: _tx_context is a shared table in SQLite (unless using temporary tables per connection,
-		// but standard sql.DB might pool connections).
-		// Ideally, we'd use a session variable or `sqlite3_commit_hook`.
-		// Given the constraints and simplicity, assuming low concurrency or standard locking,
-		// we insert into _tx_context before ops and delete after.
-		// However, with connection pooling, this is risky if operations interleave on different connections?
-		// No, `tx` binds to a single connection.
-		// BUT `_tx_context` is a real table, visible to other connections if committed.
-		// Wait, if we are inside a transaction, the insert to `_tx_context` is not visible to others yet.
-		// But triggers need to see it. Triggers run in the same transaction. So this works!
-
-		// Clear previous context just in case (though should be empty)
-		_, _ = tx.Exec("DELETE FROM _tx_context")
-		_, err = tx.Exec("INSERT INTO _tx_context (client_id) VALUES (?)", req.ClientID)
+		dedupeSince := time.Now().Add(-MutationDedupeWindow)
+
+		results := make([]models.MutationResult, len(req.Mutations))
+		for i, mut := range req.Mutations {
+			m := &mutationContext{db: db, cache: cache, session: session, clientID: req.ClientID}
+			result, seq, err := applyClientMutation(m, client, mut, dedupeSince)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			results[i] = result
+			lastSeq = seq
+
+			for _, publish := range m.published {
+				publish()
+			}
+		}
+
+		version := currentVersion(db)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.MutateResponse{
+			Version: version,
+			Seq:     lastSeq,
+			Results: results,
+		})
+	}
+}
+
+// applyClientMutation applies a mutation in its own transaction, together
+// with the client's claim on it. A mutation the client sent within the
+// dedupe window returns its recorded result, and a sequenced one must follow
+// the client's last seq. It returns the result and the client's last seq.
+func applyClientMutation(m *mutationContext, client store.SyncClient, mut models.MutationOp, dedupeSince time.Time) (models.MutationResult, int64, error) {
+	tx, err := m.db.BeginMutation(client)
+	if err != nil {
+		return models.MutationResult{}, 0, err
+	}
+	defer tx.Rollback()
+	m.tx = tx
+
+	tracked := client.ClientID != "" && mut.MutationID != ""
+	sequenced := client.ClientID != "" && mut.Seq > 0
+
+	lastSeq, err := tx.LastSeq()
+	if err != nil {
+		return models.MutationResult{}, 0, err
+	}
+
+	// A retried mutation gets its original result
+	if tracked {
+		prior, err := tx.GetResult(mut.MutationID, dedupeSince)
 		if err != nil {
-			http.Error(w, "Failed to set tx context", http.StatusInternalServerError)
-			return
+			return models.MutationResult{}, 0, err
 		}
+		if prior != nil {
+			prior.Replayed = true
+			return *prior, lastSeq, nil
+		}
+	}
 
-		for _, mut := range req.Mutations {
-			// Validate Entity Type (Registry check could go here)
-
-			dataBytes, _ := json.Marshal(mut.Data)
-			dataStr := string(dataBytes)
-			now := time.Now().UnixMilli()
-
-			if mut.Op == "upsert" {
-				// Conflict Detection
-				if mut.BaseUpdatedAt > 0 {
-					var currentUpdatedAt int64
-					err := tx.QueryRow("SELECT updated_at FROM entities WHERE namespace=? AND type=? AND entity_id=?", namespace, mut.Type, mut.ID).Scan(&currentUpdatedAt)
-					if err == nil && currentUpdatedAt > mut.BaseUpdatedAt {
-						// Conflict!
-						// For now, we just skip or error?
-						// Plan says "If affected rows = 0 -> return conflict = true"
-						// We can implement that logic.
-						// Let's use the UPDATE ... WHERE logic from plan.
-					}
-				}
+	if sequenced && mut.Seq <= lastSeq {
+		result := rejectMutation(mut, models.MutationReasonDuplicate, fmt.Sprintf("seq %d was already applied", mut.Seq))
+		result.MutationID, result.Seq = mut.MutationID, mut.Seq
+		return result, lastSeq, nil
+	}
+	if sequenced && mut.Seq > lastSeq+1 {
+		result := rejectMutation(mut, models.MutationReasonSequenceGap, fmt.Sprintf("expected seq %d", lastSeq+1))
+		result.MutationID, result.Seq = mut.MutationID, mut.Seq
+		return result, lastSeq, nil
+	}
 
-				// UPSERT using standard SQLite ON CONFLICT
-				// But we need to check BaseUpdatedAt.
-				// The plan says: UPDATE ... WHERE updated_at <= ?
+	result, err := applyMutation(m, mut)
+	if err != nil {
+		return models.MutationResult{}, 0, err
+	}
+	result.MutationID, result.Seq = mut.MutationID, mut.Seq
 
-				// Attempt Update first
-				res, err := tx.Exec(`
-					UPDATE entities 
-					SET data=?, updated_at=?, updated_by=? 
-					WHERE namespace=? AND type=? AND entity_id=? AND updated_at <= ?`,
-					dataStr, now, req.ClientID, namespace, mut.Type, mut.ID, mut.BaseUpdatedAt)
+	if sequenced {
+		lastSeq = mut.Seq
+		if err := tx.SetSeq(lastSeq); err != nil {
+			return models.MutationResult{}, 0, err
+		}
+	}
+	if tracked {
+		if err := tx.RecordResult(mut.MutationID, mut.Seq, result); err != nil {
+			return models.MutationResult{}, 0, err
+		}
+	}
 
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
-				}
+	if err := tx.Commit(); err != nil {
+		return models.MutationResult{}, 0, err
+	}
+	return result, lastSeq, nil
+}
 
-				rowsAffected, _ := res.RowsAffected()
-				if rowsAffected == 0 {
-					// Check if it exists
-					var exists int
-					err := tx.QueryRow("SELECT 1 FROM entities WHERE namespace=? AND type=? AND entity_id=?", namespace, mut.Type, mut.ID).Scan(&exists)
-					if err == sql.ErrNoRows {
-						// Insert
-						_, err = tx.Exec(`
-							INSERT INTO entities (namespace, type, entity_id, data, updated_at, updated_by)
-							VALUES (?, ?, ?, ?, ?, ?)`,
-							namespace, mut.Type, mut.ID, dataStr, now, req.ClientID)
-						if err != nil {
-							http.Error(w, err.Error(), http.StatusInternalServerError)
-							return
-						}
-					} else {
-						// Exists but update failed -> CONFLICT
-						// We can treat this as a failure or just ignore (last write wins is not applied here, server wins)
-						// The client will get the server state on next sync.
-						fmt.Println("Conflict detected for", mut.Type, mut.ID)
-					}
-				}
+// applyMutation routes a mutation to its entity type, or to the drafts
+func applyMutation(m *mutationContext, mut models.MutationOp) (models.MutationResult, error) {
+	namespace, err := m.session.namespaceFor(mut.Scope)
+	if err != nil {
+		return rejectMutation(mut, models.MutationReasonInvalidScope, err.Error()), nil
+	}
 
-			} else if mut.Op == "delete" {
-				_, err := tx.Exec("DELETE FROM entities WHERE namespace=? AND type=? AND entity_id=?", namespace, mut.Type, mut.ID)
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
-				}
+	t := entityTypes[mut.Type]
+	switch {
+	case t != nil && namespace != m.session.tournament:
+		return rejectMutation(mut, models.MutationReasonInvalidScope, "registered types live in the tournament namespace"), nil
+	case t != nil && t.apply == nil:
+		return rejectMutation(mut, models.MutationReasonReadOnly, ""), nil
+	case t != nil:
+		// The domain triggers log the write and it is published with the score
+		return t.apply(m, mut)
+	case namespace == m.session.tournament:
+		return rejectMutation(mut, models.MutationReasonUnknownType, ""), nil
+	}
+
+	result, err := applyDraft(m.tx.Tx, namespace, m.clientID, mut)
+	if err == nil && result.Status == models.MutationApplied && result.Version != 0 {
+		m.publish(func() { broadcaster.Broadcast(namespace, result.Version) })
+	}
+	return result, err
+}
+
+// applyDraft writes a draft entity unless it moved on since the client's
+// base, in which case the result carries the server's entity. A zero base
+// creates the entity, or deletes it whatever its state. The write is made in
+// the mutation's transaction, which attributes it to the client.
+func applyDraft(tx *sql.Tx, namespace int, clientID string, mut models.MutationOp) (models.MutationResult, error) {
+	// Drafts are raw entities, so they stay out of the tournament namespaces
+	// whose entities the domain triggers maintain
+	if namespace >= 0 {
+		return rejectMutation(mut, models.MutationReasonInvalidScope, "drafts live in a private namespace"), nil
+	}
+
+	current, err := getEntity(tx, namespace, mut.Type, mut.ID)
+	if err != nil {
+		return models.MutationResult{}, err
+	}
+	now := time.Now().UnixMilli()
+
+	switch mut.Op {
+	case "upsert":
+		if mut.Data == nil {
+			return rejectMutation(mut, models.MutationReasonInvalidData, "upsert carries no data"), nil
+		}
+		if current != nil && current.UpdatedAt > mut.BaseUpdatedAt {
+			reason := models.MutationReasonStale
+			if mut.BaseUpdatedAt == 0 {
+				reason = models.MutationReasonExists
 			}
+			return conflictMutation(tx, namespace, mut, current, reason)
+		}
+		if current == nil && mut.BaseUpdatedAt > 0 {
+			// Deleted on the server since the client saw it
+			return conflictMutation(tx, namespace, mut, nil, models.MutationReasonStale)
 		}
 
-		// Clean up context (optional, but good practice inside tx)
-		_, _ = tx.Exec("DELETE FROM _tx_context")
+		dataBytes, err := json.Marshal(mut.Data)
+		if err != nil {
+			return rejectMutation(mut, models.MutationReasonInvalidData, err.Error()), nil
+		}
+		if current != nil {
+			_, err = tx.Exec(`
+				UPDATE entities 
+				SET data=?, updated_at=?, updated_by=? 
+				WHERE namespace=? AND type=? AND entity_id=?`,
+				string(dataBytes), now, clientID, namespace, mut.Type, mut.ID)
+		} else {
+			_, err = tx.Exec(`
+				INSERT INTO entities (namespace, type, entity_id, data, updated_at, updated_by)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				namespace, mut.Type, mut.ID, string(dataBytes), now, clientID)
+		}
+		if err != nil {
+			return models.MutationResult{}, err
+		}
 
-		if err := tx.Commit(); err != nil {
-			http.Error(w, "Commit failed", http.StatusInternalServerError)
-			return
+	case "delete":
+		if current == nil {
+			// Already gone; nothing is logged
+			return models.MutationResult{Type: mut.Type, ID: mut.ID, Status: models.MutationApplied}, nil
+		}
+		if mut.BaseUpdatedAt > 0 && current.UpdatedAt > mut.BaseUpdatedAt {
+			return conflictMutation(tx, namespace, mut, current, models.MutationReasonStale)
+		}
+		if _, err := tx.Exec("DELETE FROM entities WHERE namespace=? AND type=? AND entity_id=?", namespace, mut.Type, mut.ID); err != nil {
+			return models.MutationResult{}, err
 		}
 
-		// Broadcast new version
-		var version int64
-		_ = db.DB.QueryRow("SELECT value FROM meta WHERE key='version'").Scan(&version)
-		broadcaster.Broadcast(namespace, version)
+	default:
+		return rejectMutation(mut, models.MutationReasonInvalidOp, ""), nil
+	}
 
-		w.WriteHeader(http.StatusOK)
+	// The entity triggers logged the write at the new version
+	var version int64
+	if err := tx.QueryRow("SELECT value FROM meta WHERE key='version'").Scan(&version); err != nil {
+		return models.MutationResult{}, err
 	}
+	return models.MutationResult{Type: mut.Type, ID: mut.ID, Status: models.MutationApplied, Version: version}, nil
 }
 
+func rejectMutation(mut models.MutationOp, reason string, message string) models.MutationResult {
+	return models.MutationResult{Type: mut.Type, ID: mut.ID, Status: models.MutationRejected, Reason: reason, Message: message}
+}
+
+// rowQuerier reads from a database or a transaction
+type rowQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// conflictMutation reports the server's side of a conflict: its entity, nil
+// once deleted, and the version it was last logged at
+func conflictMutation(q rowQuerier, namespace int, mut models.MutationOp, current *models.Entity, reason string) (models.MutationResult, error) {
+	id := mut.ID
+	if current != nil {
+		id = current.EntityId
+	}
+	version, err := entityVersion(q, namespace, mut.Type, id)
+	return models.MutationResult{
+		Type:    mut.Type,
+		ID:      id,
+		Status:  models.MutationConflict,
+		Reason:  reason,
+		Version: version,
+		Server:  current,
+	}, err
+}
+
+// entityVersion returns the changelog version an entity was last logged at
+func entityVersion(q rowQuerier, namespace int, entityType string, id int) (int64, error) {
+	var version int64
+	err := q.QueryRow(`
+		SELECT COALESCE(MAX(version), 0) FROM changelog
+		WHERE namespace=? AND entity_type=? AND entity_id=?`,
+		namespace, entityType, id).Scan(&version)
+	return version, err
+}
+
+// getEntity reads an entity, or nil if there is none
+func getEntity(q rowQuerier, namespace int, entityType string, id int) (*models.Entity, error) {
+	var e models.Entity
+	var dataStr string
+	err := q.QueryRow(`
+		SELECT namespace, type, entity_id, data, updated_at, updated_by
+		FROM entities WHERE namespace=? AND type=? AND entity_id=?`,
+		namespace, entityType, id).Scan(&e.Namespace, &e.Type, &e.EntityId, &dataStr, &e.UpdatedAt, &e.UpdatedBy)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = json.Unmarshal([]byte(dataStr), &e.Data)
+	return &e, nil
+}
+
+// Sync returns the changes to the session's namespaces since a version,
+// waiting up to ?wait= seconds for one. ?snapshot=true returns every entity
+// instead, for a new client to start from. A version behind the compaction
+// horizon gets 410 Gone with resyncRequired set.
 func Sync(db *store.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		namespace, err := getNamespace(r)
+		session, err := getSession(r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -218,8 +446,50 @@ func Sync(db *store.Store) http.HandlerFunc {
 			wait = 30
 		}
 
+		namespaces := session.namespaces(r)
+
+		// Bootstrap from the current state rather than the whole changelog
+		if snapshot, _ := strconv.ParseBool(r.URL.Query().Get("snapshot")); snapshot {
+			entities, version, err := getSnapshot(db, namespaces)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.SyncResponse{
+				Version:  version,
+				Changes:  []models.ChangelogEntry{},
+				Snapshot: entities,
+			})
+			return
+		}
+
+		// Changes before the horizon were compacted away
+		var horizon int64
+		for _, namespace := range namespaces {
+			h, err := db.GetChangelogHorizon(namespace)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if h > horizon {
+				horizon = h
+			}
+		}
+		if since < horizon {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusGone)
+			json.NewEncoder(w).Encode(models.SyncResponse{
+				Version:        currentVersion(db),
+				Changes:        []models.ChangelogEntry{},
+				ResyncRequired: true,
+				Horizon:        horizon,
+			})
+			return
+		}
+
 		// Check for immediate updates
-		changes, currentVersion, err := getChanges(db, namespace, since)
+		changes, version, err := getChanges(db, namespaces, since)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -227,13 +497,13 @@ func Sync(db *store.Store) http.HandlerFunc {
 
 		// If no changes and wait requested
 		if len(changes) == 0 && wait > 0 {
-			ch := broadcaster.Subscribe(namespace)
-			defer broadcaster.Unsubscribe(namespace, ch)
+			ch := broadcaster.Subscribe(namespaces...)
+			defer broadcaster.Unsubscribe(ch, namespaces...)
 
 			select {
 			case <-ch:
 				// New version available, fetch changes
-				changes, currentVersion, err = getChanges(db, namespace, since)
+				changes, version, err = getChanges(db, namespaces, since)
 				if err != nil {
 					http.Error(w, err.Error(), http.StatusInternalServerError)
 					return
@@ -247,7 +517,7 @@ func Sync(db *store.Store) http.HandlerFunc {
 		}
 
 		resp := models.SyncResponse{
-			Version: currentVersion,
+			Version: version,
 			Changes: changes,
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -255,9 +525,13 @@ func Sync(db *store.Store) http.HandlerFunc {
 	}
 }
 
-func Events(db *store.Store) http.HandlerFunc {
+// Events streams changelog versions of the session's namespaces as unnamed
+// events, and "leaderboard" events for its tournament: a full board on
+// connect, then the rows that change as scores land. A gap in delta versions
+// means a dropped message and the client should re-fetch the leaderboard.
+func Events(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		namespace, err := getNamespace(r)
+		session, err := getSession(r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -274,12 +548,19 @@ func Events(db *store.Store) http.HandlerFunc {
 			return
 		}
 
-		ch := broadcaster.Subscribe(namespace)
-		defer broadcaster.Unsubscribe(namespace, ch)
+		namespaces := session.namespaces(r)
+		ch := broadcaster.Subscribe(namespaces...)
+		defer broadcaster.Unsubscribe(ch, namespaces...)
+
+		boardCh := leaderboards.Subscribe(session.tournament)
+		defer leaderboards.Unsubscribe(session.tournament, boardCh)
 
 		// Send initial ping or version?
 		// Just keep connection open.
 		fmt.Fprintf(w, ": connected\n\n")
+		if snapshot, err := leaderboards.Snapshot(r.Context(), db, cache, session.tournament); err == nil {
+			writeLeaderboardEvent(w, snapshot)
+		}
 		flusher.Flush()
 
 		for {
@@ -287,6 +568,9 @@ func Events(db *store.Store) http.HandlerFunc {
 			case v := <-ch:
 				fmt.Fprintf(w, "data: %d\n\n", v)
 				flusher.Flush()
+			case delta := <-boardCh:
+				writeLeaderboardEvent(w, delta)
+				flusher.Flush()
 			case <-r.Context().Done():
 				return
 			}
@@ -294,12 +578,74 @@ func Events(db *store.Store) http.HandlerFunc {
 	}
 }
 
-func getChanges(db *store.Store, namespace int, since int64) ([]models.ChangelogEntry, int64, error) {
+func writeLeaderboardEvent(w http.ResponseWriter, delta game.LeaderboardDelta) {
+	data, err := json.Marshal(delta)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: leaderboard\ndata: %s\n\n", data)
+}
+
+// namespaceArgs renders namespaces as placeholders and arguments of an IN list
+func namespaceArgs(namespaces []int) (string, []interface{}) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(namespaces)), ",")
+	args := make([]interface{}, 0, len(namespaces)+1)
+	for _, namespace := range namespaces {
+		args = append(args, namespace)
+	}
+	return placeholders, args
+}
+
+// getSnapshot reads every entity of the namespaces and the version they
+// stand at, in one read
+func getSnapshot(db *store.Store, namespaces []int) ([]models.Entity, int64, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	placeholders, args := namespaceArgs(namespaces)
+	rows, err := tx.Query(`
+		SELECT namespace, type, entity_id, data, updated_at, updated_by
+		FROM entities
+		WHERE namespace IN (`+placeholders+`)
+		ORDER BY namespace, type, entity_id`, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entities := []models.Entity{}
+	for rows.Next() {
+		var e models.Entity
+		var dataStr string
+		if err := rows.Scan(&e.Namespace, &e.Type, &e.EntityId, &dataStr, &e.UpdatedAt, &e.UpdatedBy); err != nil {
+			return nil, 0, err
+		}
+		_ = json.Unmarshal([]byte(dataStr), &e.Data)
+		entities = append(entities, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var version int64
+	if err := tx.QueryRow("SELECT value FROM meta WHERE key='version'").Scan(&version); err != nil {
+		return nil, 0, err
+	}
+	return entities, version, nil
+}
+
+func getChanges(db *store.Store, namespaces []int, since int64) ([]models.ChangelogEntry, int64, error) {
+	placeholders, args := namespaceArgs(namespaces)
+	args = append(args, since)
+
 	rows, err := db.DB.Query(`
 		SELECT namespace, version, client_id, entity_type, entity_id, op, data 
 		FROM changelog 
-		WHERE namespace = ? AND version > ? 
-		ORDER BY version ASC`, namespace, since)
+		WHERE namespace IN (`+placeholders+`) AND version > ? 
+		ORDER BY version ASC`, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -325,7 +671,7 @@ func getChanges(db *store.Store, namespace int, since int64) ([]models.Changelog
 
 	// If no changes, get current version from meta
 	if len(changes) == 0 {
-		_ = db.DB.QueryRow("SELECT value FROM meta WHERE key='version'").Scan(&maxVersion)
+		maxVersion = currentVersion(db)
 	}
 
 	return changes, maxVersion, nil
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// -- Entity Types --
+
+// EntityType is a kind of entity kept in a tournament's namespace. Its data
+// follows Schema, a JSON Schema published at /v1/sync/types, and is written
+// by the domain tables' triggers. Types with an apply rule accept mutations,
+// which are validated, authorized and written through the domain table so
+// that the leaderboard and sync read the same rows. Other types are read
+// only. Types outside the registry live only in private namespaces.
+type EntityType struct {
+	Name     string          `json:"name"`
+	Writable bool            `json:"writable"`
+	Schema   json.RawMessage `json:"schema"`
+
+	apply func(m *mutationContext, mut models.MutationOp) (models.MutationResult, error)
+}
+
+// mutationContext is what a registered type needs to apply a mutation. Its
+// writes go through tx; what they change is published once tx commits.
+type mutationContext struct {
+	db       *store.Store
+	cache    *infra.CacheManager
+	session  syncSession
+	clientID string
+	tx       *store.MutationTx
+
+	published []func()
+}
+
+// publish runs fn once the mutation's transaction commits
+func (m *mutationContext) publish(fn func()) {
+	m.published = append(m.published, fn)
+}
+
+var entityTypes = make(map[string]*EntityType)
+
+func registerEntityType(t *EntityType) {
+	t.Writable = t.apply != nil
+	entityTypes[t.Name] = t
+}
+
+func init() {
+	registerEntityType(&EntityType{
+		Name: "tournament",
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer"},
+				"name": {"type": "string"},
+				"teamCount": {"type": "integer"},
+				"complete": {"type": "boolean"},
+				"startDate": {"type": ["string", "null"]},
+				"endDate": {"type": ["string", "null"]},
+				"created": {"type": "string"}
+			}
+		}`),
+	})
+	registerEntityType(&EntityType{
+		Name: "team",
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer"},
+				"name": {"type": "string"},
+				"tournamentId": {"type": "integer"}
+			}
+		}`),
+	})
+	registerEntityType(&EntityType{
+		Name: "tournament_round",
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer"},
+				"tournamentId": {"type": "integer"},
+				"roundNumber": {"type": "integer"},
+				"date": {"type": "string"},
+				"courseId": {"type": "integer"},
+				"formatId": {"type": ["integer", "null"]},
+				"awardedHandicap": {"type": "number"},
+				"isMatchPlay": {"type": "boolean"},
+				"name": {"type": "string"},
+				"status": {"enum": ["pending", "active", "completed"]},
+				"createdAt": {"type": "string"}
+			}
+		}`),
+	})
+	registerEntityType(&EntityType{
+		Name: "invite",
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer"},
+				"token": {"type": "string"},
+				"tournamentId": {"type": "integer"},
+				"expiresAt": {"type": "string"},
+				"createdAt": {"type": "string"},
+				"active": {"type": "boolean"}
+			}
+		}`),
+	})
+	registerEntityType(&EntityType{
+		Name: "score",
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"required": ["tournamentRoundId", "courseHoleId", "strokes"],
+			"anyOf": [{"required": ["playerId"]}, {"required": ["teamId"]}],
+			"properties": {
+				"id": {"type": "integer"},
+				"tournamentRoundId": {"type": "integer", "minimum": 1},
+				"playerId": {"type": ["integer", "null"]},
+				"teamId": {"type": ["integer", "null"]},
+				"courseHoleId": {"type": "integer", "minimum": 1},
+				"strokes": {"type": "integer", "minimum": 1},
+				"createdAt": {"type": "string"}
+			}
+		}`),
+		apply: applyScore,
+	})
+}
+
+// GetEntityTypes lists the entity types of the sync registry
+func GetEntityTypes(w http.ResponseWriter, r *http.Request) {
+	types := make([]*EntityType, 0, len(entityTypes))
+	for _, t := range entityTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(types)
+}
+
+// decodeMutationData reads a mutation's data into its type's fields
+func decodeMutationData(mut models.MutationOp, v interface{}) error {
+	data, err := json.Marshal(mut.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// -- Scores --
+
+// scoreData is the data of a score entity a client may write; id and
+// createdAt are the server's and ignored
+type scoreData struct {
+	TournamentRoundID int  `json:"tournamentRoundId"`
+	PlayerID          *int `json:"playerId"`
+	TeamID            *int `json:"teamId"`
+	CourseHoleID      int  `json:"courseHoleId"`
+	Strokes           int  `json:"strokes"`
+}
+
+// applyScore submits a score as the score endpoints do. A score is keyed by
+// round, player or team and hole, so a create that meets a score already on
+// the hole conflicts unless it carries that score's updatedAt as its base.
+func applyScore(m *mutationContext, mut models.MutationOp) (models.MutationResult, error) {
+	if mut.Op != "upsert" {
+		return rejectMutation(mut, models.MutationReasonInvalidOp, "scores can only be upserted"), nil
+	}
+
+	var data scoreData
+	if mut.Data == nil || decodeMutationData(mut, &data) != nil {
+		return rejectMutation(mut, models.MutationReasonInvalidData, "score data does not match the schema"), nil
+	}
+	if data.TournamentRoundID <= 0 || data.CourseHoleID <= 0 || data.Strokes <= 0 {
+		return rejectMutation(mut, models.MutationReasonInvalidData, "tournamentRoundId, courseHoleId and strokes are required"), nil
+	}
+	if data.PlayerID == nil && data.TeamID == nil {
+		return rejectMutation(mut, models.MutationReasonInvalidData, "playerId or teamId is required"), nil
+	}
+
+	reason, err := authorizeScore(m, data)
+	if err != nil {
+		return models.MutationResult{}, err
+	}
+	if reason != "" {
+		return rejectMutation(mut, models.MutationReasonForbidden, reason), nil
+	}
+
+	// Conflicts are checked against the score on the hole, whatever ID the
+	// client knows it by, in the transaction that writes it
+	namespace := m.session.tournament
+	id, err := m.tx.SubmitRoundScore(data.TournamentRoundID, models.SubmitRoundScoreRequest{
+		PlayerID:     data.PlayerID,
+		TeamID:       data.TeamID,
+		CourseHoleID: data.CourseHoleID,
+		Strokes:      data.Strokes,
+	}, mut.BaseUpdatedAt)
+	var conflict *store.ScoreConflictError
+	if errors.As(err, &conflict) {
+		return scoreConflict(m, mut, conflict.ScoreID)
+	}
+	if errors.Is(err, store.ErrMissedCut) {
+		return rejectMutation(mut, models.MutationReasonForbidden, err.Error()), nil
+	}
+	if err != nil {
+		return models.MutationResult{}, err
+	}
+
+	version, err := entityVersion(m.tx.Tx, namespace, mut.Type, id)
+	if err != nil {
+		return models.MutationResult{}, err
+	}
+
+	m.publish(func() {
+		m.cache.InvalidateRoundStats(data.TournamentRoundID)
+		publishRoundScore(m.db, m.cache, data.TournamentRoundID)
+	})
+	return models.MutationResult{Type: mut.Type, ID: id, Status: models.MutationApplied, Version: version}, nil
+}
+
+// scoreConflict reports a score write that met a newer score on the hole, or
+// none once it was deleted
+func scoreConflict(m *mutationContext, mut models.MutationOp, id int) (models.MutationResult, error) {
+	namespace := m.session.tournament
+	if id == 0 {
+		return conflictMutation(m.tx.Tx, namespace, mut, nil, models.MutationReasonStale)
+	}
+	current, err := getEntity(m.tx.Tx, namespace, mut.Type, id)
+	if err != nil {
+		return models.MutationResult{}, err
+	}
+	reason := models.MutationReasonStale
+	if mut.BaseUpdatedAt == 0 {
+		reason = models.MutationReasonExists
+	}
+	return conflictMutation(m.tx.Tx, namespace, mut, current, reason)
+}
+
+// authorizeScore checks a score belongs to the session's tournament and,
+// unless the session is an admin's, to its team. It returns why not, or "".
+func authorizeScore(m *mutationContext, data scoreData) (string, error) {
+	round, err := m.db.GetTournamentRound(data.TournamentRoundID)
+	if err != nil {
+		return "", err
+	}
+	if round == nil || round.TournamentID != m.session.tournament {
+		return "round is not in the session's tournament", nil
+	}
+
+	course, err := m.db.GetCourseByTournamentRoundID(round.ID)
+	if err != nil {
+		return "", err
+	}
+	onCourse := false
+	if course != nil {
+		for _, h := range course.Meta.Holes {
+			if h.ID == data.CourseHoleID {
+				onCourse = true
+				break
+			}
+		}
+	}
+	if !onCourse {
+		return "hole is not on the round's course", nil
+	}
+
+	teamID := 0
+	if data.TeamID != nil {
+		team, err := m.db.GetTeam(*data.TeamID)
+		if err != nil {
+			return "", err
+		}
+		if team == nil || team.TournamentID != m.session.tournament {
+			return "team is not in the session's tournament", nil
+		}
+		teamID = team.ID
+	}
+	if data.PlayerID != nil {
+		players, err := m.db.GetTournamentPlayers(m.session.tournament)
+		if err != nil {
+			return "", err
+		}
+		var player *models.Player
+		for i := range players {
+			if players[i].ID == *data.PlayerID {
+				player = &players[i]
+				break
+			}
+		}
+		if player == nil {
+			return "player is not in the session's tournament", nil
+		}
+		if teamID != 0 && player.TeamID != teamID {
+			return "player is not on the team", nil
+		}
+		teamID = player.TeamID
+	}
+
+	if !m.session.admin && teamID != m.session.team {
+		return fmt.Sprintf("only team %d's scores can be posted by this session", m.session.team), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrick-salvatore/games-server/internal/infra"
+	"github.com/patrick-salvatore/games-server/internal/models"
+	"github.com/patrick-salvatore/games-server/internal/store"
+)
+
+// SetTournamentTiebreak changes how ties are broken on the leaderboard
+func SetTournamentTiebreak(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		var req *models.Tiebreak
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		t, err := db.GetTournament(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if t == nil {
+			http.Error(w, "Tournament not found", http.StatusNotFound)
+			return
+		}
+
+		if err := db.SetTournamentTiebreak(tournamentID, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cache.InvalidateLeaderboard(tournamentID)
+
+		t.Tiebreak = req
+		json.NewEncoder(w).Encode(t)
+	}
+}
+
+// GetPlayoffResults returns the finishing order of a tournament's playoff
+func GetPlayoffResults(db *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		results, err := db.GetPlayoffResults(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(results)
+	}
+}
+
+// RecordPlayoff stores the finishing order of teams that played off a tie
+func RecordPlayoff(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		var req models.SetPlayoffRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.TeamIDs) < 2 {
+			http.Error(w, "A playoff needs at least two teams", http.StatusBadRequest)
+			return
+		}
+
+		teams, err := db.GetTeamsByTournament(tournamentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		inTournament := make(map[int]bool)
+		for _, t := range teams {
+			inTournament[t.ID] = true
+		}
+		for _, id := range req.TeamIDs {
+			if !inTournament[id] {
+				http.Error(w, fmt.Sprintf("Team %d is not in this tournament", id), http.StatusBadRequest)
+				return
+			}
+		}
+
+		results, err := db.RecordPlayoffTx(tournamentID, req.TeamIDs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		cache.InvalidateLeaderboard(tournamentID)
+
+		json.NewEncoder(w).Encode(results)
+	}
+}
+
+// ClearPlayoffResults removes a tournament's playoff so its ties stand
+func ClearPlayoffResults(db *store.Store, cache *infra.CacheManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tournamentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid tournament ID", http.StatusBadRequest)
+			return
+		}
+
+		if err := db.ClearPlayoffResults(tournamentID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		cache.InvalidateLeaderboard(tournamentID)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -56,11 +56,15 @@ func (cm *CacheManager) InvalidateLeaderboard(tournamentID int) {
 	cm.Adapter.Release(key)
 }
 
-// InvalidateRoundStats evicts the round stats cache for a given round
+// InvalidateRoundStats evicts the team and player round stats caches for a given round
 func (cm *CacheManager) InvalidateRoundStats(roundID int) {
-	keyStr := fmt.Sprintf("round_stats:%d", roundID)
-	key := farm.Hash64([]byte(keyStr))
-	cm.Adapter.Release(key)
+	for _, keyStr := range []string{
+		fmt.Sprintf("round_stats:%d", roundID),
+		fmt.Sprintf("round_player_stats:%d", roundID),
+	} {
+		key := farm.Hash64([]byte(keyStr))
+		cm.Adapter.Release(key)
+	}
 }
 
 // Get retrieves a value from the cache by string key and decodes it into dest
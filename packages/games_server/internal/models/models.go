@@ -2,14 +2,20 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 type TournamentFormat struct {
 	ID            int    `json:"id"`
 	Name          string `json:"name"`
+	Code          string `json:"code"` // Stable scoring code, see game.LookupFormat
 	Description   string `json:"description,omitempty"`
 	IsTeamScoring bool   `json:"isTeamScoring"`
+	// PointValues overrides the default point table of points-based formats
+	PointValues json.RawMessage `json:"pointValues,omitempty"`
+	// TeamHandicap overrides the team handicap weights of team ball formats
+	TeamHandicap json.RawMessage `json:"teamHandicap,omitempty"`
 }
 
 type Player struct {
@@ -48,6 +54,41 @@ type HoleData struct {
 	Yardage         int     `json:"yardage"`
 }
 
+// CourseTee is a tee set with its World Handicap System ratings.
+// CourseRating and Par are nil when the tee has not been rated.
+type CourseTee struct {
+	ID           int      `json:"id"`
+	CourseID     int      `json:"courseId"`
+	Name         string   `json:"name"`
+	Slope        int      `json:"slope"`
+	CourseRating *float64 `json:"courseRating"`
+	Par          *int     `json:"par"`
+}
+
+// ScoreDifferential is a posted score used to calculate a handicap index
+type ScoreDifferential struct {
+	ID                int      `json:"id"`
+	PlayerID          int      `json:"playerId"`
+	TournamentRoundID int      `json:"roundId"`
+	AdjustedGross     int      `json:"adjustedGross"`
+	CourseRating      float64  `json:"courseRating"`
+	Slope             int      `json:"slope"`
+	Differential      float64  `json:"differential"`
+	HandicapIndex     *float64 `json:"handicapIndex"` // Index after this score was posted
+	PlayedAt          string   `json:"playedAt"`
+}
+
+type PostDifferentialsRequest struct {
+	// UpdateHandicaps copies each player's new index onto their handicap
+	UpdateHandicaps bool `json:"updateHandicaps"`
+}
+
+type UpdateTeeRatingRequest struct {
+	Slope        int      `json:"slope"`
+	CourseRating *float64 `json:"courseRating"`
+	Par          *int     `json:"par"`
+}
+
 type AvailablePlayer struct {
 	PlayerID     int     `json:"playerId"`
 	Name         string  `json:"name"`
@@ -57,18 +98,18 @@ type AvailablePlayer struct {
 }
 
 type TournamentRound struct {
-	ID              int    `json:"id"`
-	TournamentID    int    `json:"tournamentId"`
-	FormatID        int    `json:"formatId"`
-	CourseID        int    `json:"courseId"`
-	RoundNumber     int    `json:"roundNumber"`
-	AwardedHandicap int    `json:"awardedHandicap"`
-	IsMatchPlay     bool   `json:"isMatchPlay"`
-	Date            string `json:"date"`
-	Name            string `json:"name"`
-	Status          string `json:"status"`
-	CourseName      string `json:"courseName,omitempty"`
-	CreatedAt       string `json:"createdAt"`
+	ID              int     `json:"id"`
+	TournamentID    int     `json:"tournamentId"`
+	FormatID        int     `json:"formatId"`
+	CourseID        int     `json:"courseId"`
+	RoundNumber     int     `json:"roundNumber"`
+	AwardedHandicap float64 `json:"awardedHandicap"`
+	IsMatchPlay     bool    `json:"isMatchPlay"`
+	Date            string  `json:"date"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	CourseName      string  `json:"courseName,omitempty"`
+	CreatedAt       string  `json:"createdAt"`
 }
 
 type Tournament struct {
@@ -79,9 +120,66 @@ type Tournament struct {
 	StartDate string            `json:"startDate"`
 	EndDate   string            `json:"endDate"`
 	CreatedAt string            `json:"created"`
+	MaxScore  *MaxScore         `json:"maxScore,omitempty"`
+	Tiebreak  *Tiebreak         `json:"tiebreak,omitempty"`
+	Cut       *CutRule          `json:"cut,omitempty"`
 	Rounds    []TournamentRound `json:"rounds,omitempty"`
 }
 
+// Tiebreak methods
+const (
+	TiebreakCountback = "countback"
+	TiebreakNone      = "none" // Ties stand as shared positions
+)
+
+// Tiebreak decides the order of teams level on score
+type Tiebreak struct {
+	Method string `json:"method"`
+	Holes  []int  `json:"holes,omitempty"` // Countback segments, e.g. [9, 6, 3, 1]
+}
+
+// PlayoffResult is a team's finishing place in a playoff
+type PlayoffResult struct {
+	TeamID int `json:"teamId"`
+	Place  int `json:"place"`
+}
+
+type SetPlayoffRequest struct {
+	TeamIDs []int `json:"teamIds"` // Finishing order, winner first
+}
+
+// CutRule trims the field once a round is completed. Top keeps the top N
+// teams and ties; WithinStrokes keeps teams within that many strokes of the
+// lead. Exactly one of them is set.
+type CutRule struct {
+	AfterRound    int  `json:"afterRound"` // Round number the cut is made after
+	Top           int  `json:"top,omitempty"`
+	WithinStrokes *int `json:"withinStrokes,omitempty"`
+}
+
+// MissedCut is a team eliminated by the cut
+type MissedCut struct {
+	TeamID     int `json:"teamId"`
+	AfterRound int `json:"afterRound"`
+}
+
+type UpdateRoundStatusRequest struct {
+	Status string `json:"status"` // "pending", "active" or "completed"
+}
+
+// Max hole score rules
+const (
+	MaxScoreNetDoubleBogey = "net_double_bogey"
+	MaxScoreDoublePar      = "double_par"
+	MaxScoreFixed          = "fixed"
+)
+
+// MaxScore caps the strokes that count on a hole
+type MaxScore struct {
+	Rule  string `json:"rule"`            // "net_double_bogey", "double_par" or "fixed"
+	Value int    `json:"value,omitempty"` // Max strokes for the "fixed" rule
+}
+
 type CreateRoundRequest struct {
 	RoundNumber int    `json:"roundNumber"`
 	RoundDate   string `json:"roundDate"`
@@ -99,6 +197,9 @@ type CreateTournamentRequest struct {
 	StartDate       string               `json:"startDate"`
 	EndDate         string               `json:"endDate"`
 	StartTime       string               `json:"startTime,omitempty"` // Legacy field
+	MaxScore        *MaxScore            `json:"maxScore,omitempty"`
+	Tiebreak        *Tiebreak            `json:"tiebreak,omitempty"`
+	Cut             *CutRule             `json:"cut,omitempty"`
 	Players         []Player             `json:"players"`
 	Rounds          []CreateRoundRequest `json:"rounds"`
 }
@@ -107,6 +208,9 @@ type SetupTournamentRequest struct {
 	Name            string       `json:"name"`
 	TeamCount       int          `json:"teamCount"`
 	AwardedHandicap float64      `json:"awardedHandicap"`
+	MaxScore        *MaxScore    `json:"maxScore,omitempty"`
+	Tiebreak        *Tiebreak    `json:"tiebreak,omitempty"`
+	Cut             *CutRule     `json:"cut,omitempty"`
 	Rounds          []RoundSetup `json:"rounds"`
 	Groups          []string     `json:"groups"` // List of group names
 	Teams           []TeamSetup  `json:"teams"`
@@ -119,6 +223,9 @@ type RoundSetup struct {
 	FormatID    int    `json:"formatId"`
 	CourseID    int    `json:"courseId"`
 	Status      string `json:"status"` // "pending", "active", "completed"
+	// AwardedHandicap overrides the format's default allowance (e.g. 0.85)
+	AwardedHandicap *float64 `json:"awardedHandicap,omitempty"`
+	IsMatchPlay     bool     `json:"isMatchPlay"`
 }
 
 type TeamSetup struct {
@@ -157,6 +264,15 @@ type Score struct {
 	CreatedAt         string `json:"createdAt"`
 }
 
+// TeamHoleResult is a team's counting score on a hole of a round
+type TeamHoleResult struct {
+	RoundID      int `json:"roundId"`
+	TeamID       int `json:"teamId"`
+	CourseHoleID int `json:"courseHoleId"`
+	HoleNumber   int `json:"holeNumber"`
+	Score        int `json:"score"` // Relative to par, or points for points formats
+}
+
 type SubmitScoreRequest struct {
 	TournamentID int  `json:"tournamentId,omitempty"` // Legacy support
 	RoundID      *int `json:"roundId,omitempty"`      // New field
@@ -185,6 +301,33 @@ type TeamGroupMember struct {
 	GroupID int64 `json:"groupId"`
 }
 
+type RoundMatch struct {
+	ID                int  `json:"id"`
+	TournamentRoundID int  `json:"tournamentRoundId"`
+	MatchNumber       int  `json:"matchNumber"`
+	SideATeamID       int  `json:"sideATeamId"`
+	SideBTeamID       int  `json:"sideBTeamId"`
+	SideAPlayerID     *int `json:"sideAPlayerId,omitempty"` // Singles only
+	SideBPlayerID     *int `json:"sideBPlayerId,omitempty"` // Singles only
+}
+
+type SetRoundMatchesRequest struct {
+	Matches []RoundMatch `json:"matches"`
+}
+
+// Reward scopes: who competes for a reward
+const (
+	RewardScopePlayer = "player"
+	RewardScopeTeam   = "team"
+	RewardScopeGroup  = "group"
+)
+
+type CreateRewardRequest struct {
+	Scope       string `json:"scope"`
+	Metric      string `json:"metric"`
+	Description string `json:"description,omitempty"`
+}
+
 type TournamentReward struct {
 	ID           int64     `json:"id"`
 	TournamentID int64     `json:"tournamentId"`
@@ -215,6 +358,15 @@ type ChangelogEntry struct {
 	Data       any    `json:"data,omitempty"`
 }
 
+// ScoreChange is a change to a score recorded in the changelog
+type ScoreChange struct {
+	Version int64      `json:"version"`
+	At      *time.Time `json:"at,omitempty"` // nil for changes logged before timestamps were kept
+	Op      string     `json:"op"`           // 'upsert' | 'delete'
+	ScoreID int        `json:"scoreId"`
+	Score   *Score     `json:"score,omitempty"` // nil for deletes
+}
+
 type RefreshToken struct {
 	Token        string    `json:"token"`
 	PlayerID     int       `json:"playerId"`
@@ -225,8 +377,21 @@ type RefreshToken struct {
 }
 
 // sync
+// Sync scopes: a session's tournament's shared namespace, or its player's
+// private namespace for drafts
+const (
+	SyncScopeTournament = "tournament"
+	SyncScopePrivate    = "private"
+)
+
+// MutationOp is a client's change to an entity. MutationID, unique to the
+// client, makes retries safe: a mutation seen before returns its original
+// result. Seq numbers the client's mutations from 1 so a gap is caught.
 type MutationOp struct {
-	Op            string `json:"op"` // 'upsert' | 'delete'
+	MutationID    string `json:"mutationId,omitempty"`
+	Seq           int64  `json:"seq,omitempty"`
+	Op            string `json:"op"`              // 'upsert' | 'delete'
+	Scope         string `json:"scope,omitempty"` // 'tournament' (default) | 'private'
 	Type          string `json:"type"`
 	ID            int    `json:"id"`
 	Data          any    `json:"data,omitempty"`
@@ -238,7 +403,57 @@ type MutateRequest struct {
 	Mutations []MutationOp `json:"mutations"`
 }
 
-type SyncResponse struct {
+// Mutation statuses
+const (
+	MutationApplied  = "applied"
+	MutationConflict = "conflict"
+	MutationRejected = "rejected"
+)
+
+// Mutation reason codes
+const (
+	MutationReasonStale        = "stale"         // The entity changed on the server since baseUpdatedAt
+	MutationReasonExists       = "exists"        // A create met an entity the client has not seen
+	MutationReasonInvalidOp    = "invalid_op"    // Op is neither upsert nor delete
+	MutationReasonInvalidScope = "invalid_scope" // Scope is unknown, or private without a player token
+	MutationReasonInvalidData  = "invalid_data"  // Data is missing or does not match the type's schema
+	MutationReasonUnknownType  = "unknown_type"  // The tournament namespace holds registered types only
+	MutationReasonReadOnly     = "read_only"     // The type is written by the server only
+	MutationReasonForbidden    = "forbidden"     // The session may not write this entity
+	MutationReasonDuplicate    = "duplicate"     // The seq was applied before and its result is no longer kept
+	MutationReasonSequenceGap  = "sequence_gap"  // Earlier seqs are missing; resend from the response's seq + 1
+)
+
+// MutationResult is the outcome of a mutation, in request order. A conflict
+// carries the server's entity, nil when it was deleted, and its version so
+// the client can rebase its edit on it. ID is the server's ID of the entity,
+// which a create of a domain entity such as a score learns here.
+type MutationResult struct {
+	MutationID string  `json:"mutationId,omitempty"`
+	Seq        int64   `json:"seq,omitempty"`
+	Replayed   bool    `json:"replayed,omitempty"` // The original result of a retried mutation
+	Type       string  `json:"type"`
+	ID         int     `json:"id"`
+	Status     string  `json:"status"` // 'applied' | 'conflict' | 'rejected'
+	Reason     string  `json:"reason,omitempty"`
+	Message    string  `json:"message,omitempty"`
+	Version    int64   `json:"version,omitempty"` // Changelog version of the applied write, or of the server's entity
+	Server     *Entity `json:"server,omitempty"`
+}
+
+type MutateResponse struct {
 	Version int64            `json:"version"`
-	Changes []ChangelogEntry `json:"changes"`
+	Seq     int64            `json:"seq,omitempty"` // The client's last applied seq
+	Results []MutationResult `json:"results"`
+}
+
+// SyncResponse carries the changes since the client's version, or with
+// snapshot set, every entity as of Version. ResyncRequired tells a client
+// whose version is behind the compaction horizon to take a snapshot.
+type SyncResponse struct {
+	Version        int64            `json:"version"`
+	Changes        []ChangelogEntry `json:"changes"`
+	Snapshot       []Entity         `json:"snapshot,omitempty"`
+	ResyncRequired bool             `json:"resyncRequired,omitempty"`
+	Horizon        int64            `json:"horizon,omitempty"`
 }
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// -- Changelog --
+
+// ErrCompacted is returned for a changelog version before the compaction
+// horizon, whose changes are no longer kept one by one
+var ErrCompacted = errors.New("changelog is compacted past this version")
+
+// GetScoreChanges returns the changes to a tournament's scores up to and
+// including a changelog version, oldest first
+func (s *Store) GetScoreChanges(tournamentID int, upTo int64) ([]models.ScoreChange, error) {
+	horizon, err := s.GetChangelogHorizon(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if upTo < horizon {
+		return nil, ErrCompacted
+	}
+
+	rows, err := s.Queries.GetScoreChanges(context.Background(), db.GetScoreChangesParams{
+		Namespace: int64(tournamentID),
+		Version:   upTo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.ScoreChange{}
+	for _, r := range rows {
+		c := models.ScoreChange{
+			Version: r.Version,
+			Op:      r.Op,
+			ScoreID: int(r.EntityID),
+		}
+		if r.CreatedAt.Valid {
+			at := time.UnixMilli(r.CreatedAt.Int64).UTC()
+			c.At = &at
+		}
+		if r.Op == "upsert" && len(r.Data) > 0 {
+			var score models.Score
+			if err := json.Unmarshal([]byte(r.Data), &score); err != nil {
+				return nil, fmt.Errorf("changelog version %d: %w", r.Version, err)
+			}
+			c.Score = &score
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// GetChangelogVersionAt returns the latest changelog version of a tournament
+// logged at or before a time, or 0 if there is none
+func (s *Store) GetChangelogVersionAt(tournamentID int, at time.Time) (int64, error) {
+	return s.Queries.GetChangelogVersionAt(context.Background(), db.GetChangelogVersionAtParams{
+		Namespace: int64(tournamentID),
+		CreatedAt: sql.NullInt64{Int64: at.UnixMilli(), Valid: true},
+	})
+}
+
+// GetChangelogHorizon returns the version a namespace's changelog is
+// compacted up to, or 0 if it never was
+func (s *Store) GetChangelogHorizon(namespace int) (int64, error) {
+	return s.Queries.GetChangelogHorizon(context.Background(), int64(namespace))
+}
+
+// CompactChangelog moves each namespace's horizon up to its last change
+// logged before a time and keeps only the latest op per entity up to the
+// horizon. It returns the number of changes pruned.
+func (s *Store) CompactChangelog(before time.Time) (int64, error) {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+
+	if err := q.SetChangelogHorizons(ctx, sql.NullInt64{Int64: before.UnixMilli(), Valid: true}); err != nil {
+		return 0, err
+	}
+	pruned, err := q.PruneChangelog(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
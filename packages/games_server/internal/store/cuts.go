@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// -- Cut Line --
+
+// ErrMissedCut is returned when a team that missed the cut posts a score in a
+// round after the cut
+var ErrMissedCut = errors.New("team missed the cut")
+
+// SetTournamentCutRule changes a tournament's cut. A nil rule plays every
+// team through.
+func (s *Store) SetTournamentCutRule(tournamentID int, c *models.CutRule) error {
+	return setCutRule(context.Background(), s.Queries, tournamentID, c)
+}
+
+func setCutRule(ctx context.Context, q *db.Queries, tournamentID int, c *models.CutRule) error {
+	params := db.SetTournamentCutRuleParams{ID: int64(tournamentID)}
+	if c != nil {
+		if c.AfterRound <= 0 {
+			return fmt.Errorf("cut must be made after a round")
+		}
+		if (c.Top > 0) == (c.WithinStrokes != nil) {
+			return fmt.Errorf("cut must set exactly one of top or withinStrokes")
+		}
+		if c.Top < 0 || (c.WithinStrokes != nil && *c.WithinStrokes < 0) {
+			return fmt.Errorf("cut values must not be negative")
+		}
+
+		data, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		params.CutRule = sql.NullString{String: string(data), Valid: true}
+	}
+	return q.SetTournamentCutRule(ctx, params)
+}
+
+func cutRule(raw sql.NullString) *models.CutRule {
+	if !raw.Valid {
+		return nil
+	}
+	var c models.CutRule
+	if err := json.Unmarshal([]byte(raw.String), &c); err != nil {
+		return nil
+	}
+	return &c
+}
+
+func (s *Store) GetMissedCuts(tournamentID int) ([]models.MissedCut, error) {
+	rows, err := s.Queries.GetMissedCuts(context.Background(), int64(tournamentID))
+	if err != nil {
+		return nil, err
+	}
+
+	cuts := []models.MissedCut{}
+	for _, r := range rows {
+		cuts = append(cuts, models.MissedCut{
+			TeamID:     int(r.TeamID),
+			AfterRound: int(r.AfterRound),
+		})
+	}
+	return cuts, nil
+}
+
+// SetMissedCutsTx replaces the teams that missed a tournament's cut
+func (s *Store) SetMissedCutsTx(tournamentID int, afterRound int, teamIDs []int) error {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+
+	if err := q.DeleteMissedCuts(ctx, int64(tournamentID)); err != nil {
+		return err
+	}
+	for _, teamID := range teamIDs {
+		err := q.InsertMissedCut(ctx, db.InsertMissedCutParams{
+			TournamentID: int64(tournamentID),
+			TeamID:       int64(teamID),
+			AfterRound:   int64(afterRound),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record missed cut for team %d: %w", teamID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *Store) ClearMissedCuts(tournamentID int) error {
+	return s.Queries.DeleteMissedCuts(context.Background(), int64(tournamentID))
+}
+
+// checkCut rejects scores from a team that missed the cut, or one of its
+// players, in a round after the cut
+func checkCut(ctx context.Context, q *db.Queries, roundID int, teamID, playerID *int) error {
+	var n int64
+	var err error
+	if teamID != nil {
+		n, err = q.IsTeamCutFromRound(ctx, db.IsTeamCutFromRoundParams{
+			ID:     int64(roundID),
+			TeamID: int64(*teamID),
+		})
+	} else if playerID != nil {
+		n, err = q.IsPlayerCutFromRound(ctx, db.IsPlayerCutFromRoundParams{
+			ID:   int64(roundID),
+			ID_2: int64(*playerID),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrMissedCut
+	}
+	return nil
+}
+
+func (s *Store) UpdateTournamentRoundStatus(roundID int, status string) error {
+	return s.Queries.UpdateTournamentRoundStatus(context.Background(), db.UpdateTournamentRoundStatusParams{
+		Status: sql.NullString{String: status, Valid: true},
+		ID:     int64(roundID),
+	})
+}
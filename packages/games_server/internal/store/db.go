@@ -11,8 +11,11 @@ import (
 	_ "modernc.org/sqlite"
 )
 
+// New opens the database. Writers take turns on SQLite's one write lock, so a
+// connection waits for it rather than failing with SQLITE_BUSY, and WAL lets
+// readers carry on while a write is in progress.
 func New(dbPath string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// -- Score Differentials --
+
+// IndexCalculator fills in the index after each differential of a player's
+// scoring record, oldest first, and returns the player's new handicap, or nil
+// to keep it
+type IndexCalculator func(record []models.ScoreDifferential) *float64
+
+// PostScoreDifferentialsTx records the differentials of a round, replacing any
+// posted before for the same players, and recalculates each player's indexes
+// in the same transaction. Before a player's handicap changes, the rounds the
+// player has begun keep the handicap they were played off. It returns the
+// round's differentials with their indexes.
+func (s *Store) PostScoreDifferentialsTx(roundID int, diffs []models.ScoreDifferential, calc IndexCalculator) ([]models.ScoreDifferential, error) {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+	for _, d := range diffs {
+		err := q.UpsertScoreDifferential(ctx, db.UpsertScoreDifferentialParams{
+			PlayerID:      int64(d.PlayerID),
+			AdjustedGross: int64(d.AdjustedGross),
+			CourseRating:  d.CourseRating,
+			Slope:         int64(d.Slope),
+			Differential:  d.Differential,
+			ID:            int64(roundID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to post differential for player %d: %w", d.PlayerID, err)
+		}
+	}
+
+	posted := []models.ScoreDifferential{}
+	for _, d := range diffs {
+		record, err := getPlayerDifferentials(ctx, q, d.PlayerID)
+		if err != nil {
+			return nil, err
+		}
+		handicap := calc(record)
+		if err := setHandicapIndexes(ctx, q, d.PlayerID, record, handicap); err != nil {
+			return nil, err
+		}
+
+		for _, r := range record {
+			if r.TournamentRoundID == roundID {
+				posted = append(posted, r)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return posted, nil
+}
+
+// GetPlayerDifferentials returns a player's scoring record, oldest first
+func (s *Store) GetPlayerDifferentials(playerID int) ([]models.ScoreDifferential, error) {
+	return getPlayerDifferentials(context.Background(), s.Queries, playerID)
+}
+
+func getPlayerDifferentials(ctx context.Context, q *db.Queries, playerID int) ([]models.ScoreDifferential, error) {
+	rows, err := q.GetPlayerDifferentials(ctx, int64(playerID))
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []models.ScoreDifferential{}
+	for _, d := range rows {
+		diff := models.ScoreDifferential{
+			ID:                int(d.ID),
+			PlayerID:          int(d.PlayerID),
+			TournamentRoundID: int(d.TournamentRoundID),
+			AdjustedGross:     int(d.AdjustedGross),
+			CourseRating:      d.CourseRating,
+			Slope:             int(d.Slope),
+			Differential:      d.Differential,
+			PlayedAt:          d.PlayedAt.Format("2006-01-02"),
+		}
+		if d.HandicapIndex.Valid {
+			diff.HandicapIndex = &d.HandicapIndex.Float64
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// setHandicapIndexes stores the index after each differential and, when
+// handicap is set, the player's new handicap
+func setHandicapIndexes(ctx context.Context, q *db.Queries, playerID int, diffs []models.ScoreDifferential, handicap *float64) error {
+	for _, d := range diffs {
+		var index sql.NullFloat64
+		if d.HandicapIndex != nil {
+			index = sql.NullFloat64{Float64: *d.HandicapIndex, Valid: true}
+		}
+		err := q.SetDifferentialHandicapIndex(ctx, db.SetDifferentialHandicapIndexParams{
+			HandicapIndex: index,
+			ID:            int64(d.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if handicap == nil {
+		return nil
+	}
+	if err := q.KeepRoundHandicaps(ctx, int64(playerID)); err != nil {
+		return err
+	}
+	return q.UpdatePlayerHandicap(ctx, db.UpdatePlayerHandicapParams{
+		Handicap: sql.NullFloat64{Float64: *handicap, Valid: true},
+		ID:       int64(playerID),
+	})
+}
+
+// GetRoundHandicaps returns the handicaps kept for a round's players, by
+// player ID. Players without one play the round off their current handicap.
+func (s *Store) GetRoundHandicaps(roundID int) (map[int]float64, error) {
+	rows, err := s.Queries.GetRoundHandicaps(context.Background(), int64(roundID))
+	if err != nil {
+		return nil, err
+	}
+	handicaps := make(map[int]float64)
+	for _, r := range rows {
+		handicaps[int(r.PlayerID)] = r.Handicap.Float64
+	}
+	return handicaps, nil
+}
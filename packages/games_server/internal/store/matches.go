@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// -- Match Play Pairings --
+
+func (s *Store) GetRoundMatches(roundID int) ([]models.RoundMatch, error) {
+	rows, err := s.Queries.GetRoundMatches(context.Background(), int64(roundID))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.RoundMatch
+	for _, m := range rows {
+		match := models.RoundMatch{
+			ID:                int(m.ID),
+			TournamentRoundID: int(m.TournamentRoundID),
+			MatchNumber:       int(m.MatchNumber),
+			SideATeamID:       int(m.SideATeamID),
+			SideBTeamID:       int(m.SideBTeamID),
+		}
+		if m.SideAPlayerID.Valid {
+			id := int(m.SideAPlayerID.Int64)
+			match.SideAPlayerID = &id
+		}
+		if m.SideBPlayerID.Valid {
+			id := int(m.SideBPlayerID.Int64)
+			match.SideBPlayerID = &id
+		}
+		result = append(result, match)
+	}
+	return result, nil
+}
+
+// SetRoundMatchesTx replaces every pairing for a round
+func (s *Store) SetRoundMatchesTx(roundID int, matches []models.RoundMatch) ([]models.RoundMatch, error) {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+
+	if err := q.DeleteRoundMatches(ctx, int64(roundID)); err != nil {
+		return nil, err
+	}
+
+	for i, m := range matches {
+		if (m.SideAPlayerID == nil) != (m.SideBPlayerID == nil) {
+			return nil, fmt.Errorf("match %d: singles matches need a player on both sides", i+1)
+		}
+
+		var pA, pB sql.NullInt64
+		if m.SideAPlayerID != nil {
+			pA = sql.NullInt64{Int64: int64(*m.SideAPlayerID), Valid: true}
+			pB = sql.NullInt64{Int64: int64(*m.SideBPlayerID), Valid: true}
+		}
+
+		matchNumber := m.MatchNumber
+		if matchNumber == 0 {
+			matchNumber = i + 1
+		}
+
+		_, err := q.CreateRoundMatch(ctx, db.CreateRoundMatchParams{
+			TournamentRoundID: int64(roundID),
+			MatchNumber:       int64(matchNumber),
+			SideATeamID:       int64(m.SideATeamID),
+			SideBTeamID:       int64(m.SideBTeamID),
+			SideAPlayerID:     pA,
+			SideBPlayerID:     pB,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create match %d: %w", matchNumber, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetRoundMatches(roundID)
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// -- Tiebreaks & Playoffs --
+
+// SetTournamentTiebreak changes how a tournament breaks ties. A nil tiebreak
+// restores the default countback.
+func (s *Store) SetTournamentTiebreak(tournamentID int, t *models.Tiebreak) error {
+	return setTiebreak(context.Background(), s.Queries, tournamentID, t)
+}
+
+func setTiebreak(ctx context.Context, q *db.Queries, tournamentID int, t *models.Tiebreak) error {
+	params := db.SetTournamentTiebreakParams{ID: int64(tournamentID)}
+	if t != nil {
+		switch t.Method {
+		case models.TiebreakCountback, models.TiebreakNone:
+		default:
+			return fmt.Errorf("unknown tiebreak method %q", t.Method)
+		}
+		for _, n := range t.Holes {
+			if n <= 0 {
+				return fmt.Errorf("countback holes must be positive")
+			}
+		}
+
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		params.Tiebreak = sql.NullString{String: string(data), Valid: true}
+	}
+	return q.SetTournamentTiebreak(ctx, params)
+}
+
+func tiebreak(raw sql.NullString) *models.Tiebreak {
+	if !raw.Valid {
+		return nil
+	}
+	var t models.Tiebreak
+	if err := json.Unmarshal([]byte(raw.String), &t); err != nil {
+		return nil
+	}
+	return &t
+}
+
+func (s *Store) GetPlayoffResults(tournamentID int) ([]models.PlayoffResult, error) {
+	rows, err := s.Queries.GetPlayoffResults(context.Background(), int64(tournamentID))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []models.PlayoffResult{}
+	for _, r := range rows {
+		results = append(results, models.PlayoffResult{
+			TeamID: int(r.TeamID),
+			Place:  int(r.Place),
+		})
+	}
+	return results, nil
+}
+
+// RecordPlayoffTx stores the finishing order of a playoff. Teams from earlier
+// playoffs keep their places unless they appear again.
+func (s *Store) RecordPlayoffTx(tournamentID int, teamIDs []int) ([]models.PlayoffResult, error) {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+	for i, teamID := range teamIDs {
+		err := q.UpsertPlayoffResult(ctx, db.UpsertPlayoffResultParams{
+			TournamentID: int64(tournamentID),
+			TeamID:       int64(teamID),
+			Place:        int64(i + 1),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record playoff place for team %d: %w", teamID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetPlayoffResults(tournamentID)
+}
+
+func (s *Store) ClearPlayoffResults(tournamentID int) error {
+	return s.Queries.DeletePlayoffResults(context.Background(), int64(tournamentID))
+}
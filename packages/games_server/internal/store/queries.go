@@ -3,7 +3,9 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +17,9 @@ import (
 type Store struct {
 	DB      *sql.DB
 	Queries *db.Queries
+	// Scorer keeps team_hole_results current as scores are submitted.
+	// It is nil until the game package registers its scoring rules.
+	Scorer RoundScorer
 }
 
 func NewStore(conn *sql.DB) *Store {
@@ -37,8 +42,11 @@ func (s *Store) GetTournamentFormats(tournamentId int) ([]models.TournamentForma
 		result = append(result, models.TournamentFormat{
 			ID:            int(f.ID),
 			Name:          f.Name,
+			Code:          f.Code.String,
 			Description:   f.Description.String,
 			IsTeamScoring: f.IsTeamScoring.Bool,
+			PointValues:   nullJSON(f.PointValues),
+			TeamHandicap:  nullJSON(f.TeamHandicap),
 		})
 	}
 	return result, nil
@@ -55,13 +63,24 @@ func (s *Store) GetAllFormats() ([]models.TournamentFormat, error) {
 		result = append(result, models.TournamentFormat{
 			ID:            int(f.ID),
 			Name:          f.Name,
+			Code:          f.Code.String,
 			Description:   f.Description.String,
 			IsTeamScoring: f.IsTeamScoring.Bool,
+			PointValues:   nullJSON(f.PointValues),
+			TeamHandicap:  nullJSON(f.TeamHandicap),
 		})
 	}
 	return result, nil
 }
 
+// nullJSON exposes an optional JSON text column as raw JSON
+func nullJSON(s sql.NullString) json.RawMessage {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	return json.RawMessage(s.String)
+}
+
 // -- Players --
 
 func (s *Store) GetPlayer(id int) (*models.Player, error) {
@@ -159,6 +178,9 @@ func (s *Store) GetAllTournaments() ([]models.Tournament, error) {
 			StartDate: t.StartDate.String(),
 			EndDate:   t.EndDate.String(),
 			CreatedAt: createdAt,
+			MaxScore:  maxScore(t.MaxScoreRule, t.MaxScoreValue),
+			Tiebreak:  tiebreak(t.Tiebreak),
+			Cut:       cutRule(t.CutRule),
 		})
 	}
 	return result, nil
@@ -181,6 +203,9 @@ func (s *Store) GetTournament(id int) (*models.Tournament, error) {
 		StartDate: t.StartDate.String(),
 		EndDate:   t.EndDate.String(),
 		CreatedAt: t.CreatedAt.Time.String(),
+		MaxScore:  maxScore(t.MaxScoreRule, t.MaxScoreValue),
+		Tiebreak:  tiebreak(t.Tiebreak),
+		Cut:       cutRule(t.CutRule),
 	}, nil
 }
 
@@ -195,7 +220,18 @@ func (s *Store) CreateTournament(req models.CreateTournamentRequest) (*models.To
 		return nil, err
 	}
 
-	t, err := s.Queries.CreateTournament(context.Background(), db.CreateTournamentParams{
+	// The rules are set with the tournament, so an invalid one leaves nothing
+	// behind
+	ctx := context.Background()
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+
+	t, err := q.CreateTournament(ctx, db.CreateTournamentParams{
 		Name:      req.Name,
 		TeamCount: int64(req.TeamCount),
 		StartDate: startDate,
@@ -206,6 +242,20 @@ func (s *Store) CreateTournament(req models.CreateTournamentRequest) (*models.To
 		return nil, err
 	}
 
+	if err := setMaxScore(ctx, q, int(t.ID), req.MaxScore); err != nil {
+		return nil, err
+	}
+	if err := setTiebreak(ctx, q, int(t.ID), req.Tiebreak); err != nil {
+		return nil, err
+	}
+	if err := setCutRule(ctx, q, int(t.ID), req.Cut); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	tournament := &models.Tournament{
 		ID:        int(t.ID),
 		Name:      t.Name,
@@ -214,11 +264,45 @@ func (s *Store) CreateTournament(req models.CreateTournamentRequest) (*models.To
 		StartDate: t.StartDate.String(),
 		EndDate:   t.EndDate.String(),
 		CreatedAt: t.CreatedAt.Time.Format("2006-01-02 15:04:05"),
+		MaxScore:  req.MaxScore,
+		Tiebreak:  req.Tiebreak,
+		Cut:       req.Cut,
 	}
 
 	return tournament, nil
 }
 
+// SetTournamentMaxScore changes the max hole score of a tournament. A nil
+// rule removes the cap.
+func (s *Store) SetTournamentMaxScore(tournamentID int, m *models.MaxScore) error {
+	return setMaxScore(context.Background(), s.Queries, tournamentID, m)
+}
+
+func setMaxScore(ctx context.Context, q *db.Queries, tournamentID int, m *models.MaxScore) error {
+	params := db.SetTournamentMaxScoreParams{ID: int64(tournamentID)}
+	if m != nil {
+		switch m.Rule {
+		case models.MaxScoreNetDoubleBogey, models.MaxScoreDoublePar:
+		case models.MaxScoreFixed:
+			if m.Value <= 0 {
+				return fmt.Errorf("fixed max score needs a positive value")
+			}
+			params.MaxScoreValue = sql.NullInt64{Int64: int64(m.Value), Valid: true}
+		default:
+			return fmt.Errorf("unknown max score rule %q", m.Rule)
+		}
+		params.MaxScoreRule = sql.NullString{String: m.Rule, Valid: true}
+	}
+	return q.SetTournamentMaxScore(ctx, params)
+}
+
+func maxScore(rule sql.NullString, value sql.NullInt64) *models.MaxScore {
+	if !rule.Valid {
+		return nil
+	}
+	return &models.MaxScore{Rule: rule.String, Value: int(value.Int64)}
+}
+
 // -- Teams --
 
 func (s *Store) CreateTeam(tournamentID int, name string) (int, error) {
@@ -302,6 +386,28 @@ func (s *Store) GetTeamPlayers(teamID int) ([]models.Player, error) {
 	return players, nil
 }
 
+// GetTournamentPlayers returns every player in a tournament, claimed or not
+func (s *Store) GetTournamentPlayers(tournamentID int) ([]models.Player, error) {
+	dbPlayers, err := s.Queries.GetTournamentPlayers(context.Background(), int64(tournamentID))
+	if err != nil {
+		return nil, err
+	}
+
+	players := []models.Player{}
+	for _, p := range dbPlayers {
+		players = append(players, models.Player{
+			ID:           int(p.ID),
+			Name:         p.Name,
+			Handicap:     p.Handicap.Float64,
+			TeeName:      p.TeeName.String,
+			Tee:          int(p.CourseTeesID),
+			TournamentID: int(tournamentID),
+			TeamID:       int(p.TeamID),
+		})
+	}
+	return players, nil
+}
+
 // -- Courses --
 
 func (s *Store) GetAllCourses() ([]models.Course, error) {
@@ -353,6 +459,8 @@ func (s *Store) GetCourseByTournamentRoundID(tournamentID int) (*models.Course,
 		})
 	}
 
+	rankStrokeIndexes(holes)
+
 	return &models.Course{
 		ID:   int(c.ID),
 		Name: c.Name,
@@ -363,6 +471,92 @@ func (s *Store) GetCourseByTournamentRoundID(tournamentID int) (*models.Course,
 	}, nil
 }
 
+// rankStrokeIndexes renumbers hole handicaps 1..n over the holes in play so
+// strokes can be allocated on 9 and 12 hole loops. RawHandicap keeps the
+// stroke index from the card.
+func rankStrokeIndexes(holes []models.HoleData) {
+	byNumber := make(map[int]models.HoleData)
+	for _, h := range holes {
+		if _, ok := byNumber[h.Number]; !ok {
+			byNumber[h.Number] = h
+		}
+	}
+
+	ranked := make([]models.HoleData, 0, len(byNumber))
+	for _, h := range byNumber {
+		ranked = append(ranked, h)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].RawHandicap != ranked[j].RawHandicap {
+			return ranked[i].RawHandicap < ranked[j].RawHandicap
+		}
+		return ranked[i].Number < ranked[j].Number
+	})
+
+	rank := make(map[int]int)
+	for i, h := range ranked {
+		rank[h.Number] = i + 1
+	}
+	for i := range holes {
+		holes[i].Handicap = rank[holes[i].Number]
+	}
+}
+
+func (s *Store) GetCourseTees(courseID int) ([]models.CourseTee, error) {
+	rows, err := s.Queries.GetCourseTees(context.Background(), int64(courseID))
+	if err != nil {
+		return nil, err
+	}
+
+	tees := []models.CourseTee{}
+	for _, t := range rows {
+		tees = append(tees, courseTee(t.ID, t.CourseID, t.Name, t.Slope, t.CourseRating, t.Par))
+	}
+	return tees, nil
+}
+
+func (s *Store) UpdateCourseTeeRating(courseID, teeID int, req models.UpdateTeeRatingRequest) (*models.CourseTee, error) {
+	params := db.UpdateCourseTeeRatingParams{
+		ID:       int64(teeID),
+		CourseID: int64(courseID),
+		Slope:    int64(req.Slope),
+	}
+	if req.CourseRating != nil {
+		params.CourseRating = sql.NullFloat64{Float64: *req.CourseRating, Valid: true}
+	}
+	if req.Par != nil {
+		params.Par = sql.NullInt64{Int64: int64(*req.Par), Valid: true}
+	}
+
+	t, err := s.Queries.UpdateCourseTeeRating(context.Background(), params)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tee := courseTee(t.ID, t.CourseID, t.Name, t.Slope, t.CourseRating, t.Par)
+	return &tee, nil
+}
+
+func courseTee(id, courseID int64, name sql.NullString, slope int64, rating sql.NullFloat64, par sql.NullInt64) models.CourseTee {
+	tee := models.CourseTee{
+		ID:       int(id),
+		CourseID: int(courseID),
+		Name:     name.String,
+		Slope:    int(slope),
+	}
+	if rating.Valid {
+		tee.CourseRating = &rating.Float64
+	}
+	if par.Valid {
+		p := int(par.Int64)
+		tee.Par = &p
+	}
+	return tee
+}
+
 // -- Active Players --
 
 func (s *Store) GetAvailablePlayers(tournamentID int) ([]models.AvailablePlayer, error) {
@@ -527,35 +721,39 @@ func (s *Store) GetRoundScores(tournamentRoundID int, playerID, teamID *int) ([]
 
 	var result []models.Score
 	for _, sc := range scores {
-		var pID *int
-		if sc.PlayerID.Valid {
-			id := int(sc.PlayerID.Int64)
-			pID = &id
-		}
-		var tID *int
-		if sc.TeamID.Valid {
-			id := int(sc.TeamID.Int64)
-			tID = &id
-		}
+		result = append(result, roundScore(sc))
+	}
+	return result, nil
+}
 
-		var createdStr string
-		if sc.CreatedAt.Valid {
-			createdStr = sc.CreatedAt.Time.Format("2006-01-02 15:04:05")
-		}
+func roundScore(sc db.GetRoundScoresRow) models.Score {
+	var pID *int
+	if sc.PlayerID.Valid {
+		id := int(sc.PlayerID.Int64)
+		pID = &id
+	}
+	var tID *int
+	if sc.TeamID.Valid {
+		id := int(sc.TeamID.Int64)
+		tID = &id
+	}
 
-		roundId := int(sc.TournamentRoundID)
-		result = append(result, models.Score{
-			ID:                int(sc.ID),
-			TournamentRoundID: &roundId,
-			PlayerID:          pID,
-			TeamID:            tID,
-			CourseHoleID:      int(sc.CourseHoleID),
-			HoleNumber:        int(sc.HoleNumber),
-			Strokes:           int(sc.Strokes),
-			CreatedAt:         createdStr,
-		})
+	var createdStr string
+	if sc.CreatedAt.Valid {
+		createdStr = sc.CreatedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	roundId := int(sc.TournamentRoundID)
+	return models.Score{
+		ID:                int(sc.ID),
+		TournamentRoundID: &roundId,
+		PlayerID:          pID,
+		TeamID:            tID,
+		CourseHoleID:      int(sc.CourseHoleID),
+		HoleNumber:        int(sc.HoleNumber),
+		Strokes:           int(sc.Strokes),
+		CreatedAt:         createdStr,
 	}
-	return result, nil
 }
 
 func (s *Store) SubmitScore(req models.SubmitScoreRequest) (*models.Score, error) {
@@ -634,26 +832,46 @@ func (s *Store) GetTournamentRounds(tournamentID int) ([]models.TournamentRound,
 
 	var result []models.TournamentRound
 	for _, r := range rounds {
-		var createdAt string
-		if r.CreatedAt.Valid {
-			createdAt = r.CreatedAt.Time.Format("2006-01-02 15:04:05")
-		}
+		result = append(result, tournamentRound(r))
+	}
+	return result, nil
+}
 
-		result = append(result, models.TournamentRound{
-			ID:           int(r.ID),
-			FormatID:     int(r.FormatID),
-			TournamentID: int(r.TournamentID),
-			RoundNumber:  int(r.RoundNumber),
-			Date:         r.Date.String(),
-			CourseID:     int(r.CourseID),
-			Name:         r.Name,
-			Status:       r.Status.String,
-			CreatedAt:    createdAt,
-		})
+// GetCourseRounds returns every tournament's rounds played on a course
+func (s *Store) GetCourseRounds(courseID int) ([]models.TournamentRound, error) {
+	rounds, err := s.Queries.GetCourseRounds(context.Background(), int64(courseID))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.TournamentRound
+	for _, r := range rounds {
+		result = append(result, tournamentRound(r))
 	}
 	return result, nil
 }
 
+func tournamentRound(r db.TournamentRound) models.TournamentRound {
+	var createdAt string
+	if r.CreatedAt.Valid {
+		createdAt = r.CreatedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	return models.TournamentRound{
+		ID:              int(r.ID),
+		FormatID:        int(r.FormatID),
+		TournamentID:    int(r.TournamentID),
+		RoundNumber:     int(r.RoundNumber),
+		AwardedHandicap: r.AwardedHandicap.Float64,
+		IsMatchPlay:     r.IsMatchPlay.Bool,
+		Date:            r.Date.String(),
+		CourseID:        int(r.CourseID),
+		Name:            r.Name,
+		Status:          r.Status.String,
+		CreatedAt:       createdAt,
+	}
+}
+
 func (s *Store) GetTournamentRound(roundID int) (*models.TournamentRound, error) {
 	r, err := s.Queries.GetTournamentRound(context.Background(), int64(roundID))
 	if err == sql.ErrNoRows {
@@ -669,15 +887,18 @@ func (s *Store) GetTournamentRound(roundID int) (*models.TournamentRound, error)
 	}
 
 	return &models.TournamentRound{
-		ID:           int(r.ID),
-		TournamentID: int(r.TournamentID),
-		RoundNumber:  int(r.RoundNumber),
-		Date:         r.Date.String(),
-		CourseID:     int(r.CourseID),
-		Name:         r.Name,
-		Status:       r.Status.String,
-		CourseName:   r.CourseName,
-		CreatedAt:    createdAt,
+		ID:              int(r.ID),
+		TournamentID:    int(r.TournamentID),
+		FormatID:        int(r.FormatID),
+		RoundNumber:     int(r.RoundNumber),
+		AwardedHandicap: r.AwardedHandicap.Float64,
+		IsMatchPlay:     r.IsMatchPlay.Bool,
+		Date:            r.Date.String(),
+		CourseID:        int(r.CourseID),
+		Name:            r.Name,
+		Status:          r.Status.String,
+		CourseName:      r.CourseName,
+		CreatedAt:       createdAt,
 	}, nil
 }
 
@@ -722,6 +943,61 @@ func (s *Store) SubmitRoundScore(roundID int, req models.SubmitRoundScoreRequest
 
 	q := s.Queries.WithTx(tx)
 
+	// The scoring rules are loaded under the write lock, so a rebuild under
+	// new rules can't commit between loading them and writing the result
+	if err := lockForWrite(ctx, q); err != nil {
+		return 0, err
+	}
+	scoreHole, err := s.holeScorer(roundID, req)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := writeRoundScore(ctx, q, scoreHole, roundID, req, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// ScoreConflictError is returned when a synced score has moved on since the
+// client's base. ScoreID is the score on the hole, or 0 once it is deleted.
+type ScoreConflictError struct {
+	ScoreID int
+}
+
+func (e *ScoreConflictError) Error() string {
+	return fmt.Sprintf("score %d changed since the client's base", e.ScoreID)
+}
+
+// lockForWrite takes the write lock for a transaction before it reads
+// anything, as BEGIN IMMEDIATE would. Until it commits, what other
+// connections read can't change under it.
+func lockForWrite(ctx context.Context, q *db.Queries) error {
+	return q.ClearTxContext(ctx)
+}
+
+// holeScorer returns what updates a team's result for the hole a score is
+// on, or nil for a player's score. Load it under the write lock.
+func (s *Store) holeScorer(roundID int, req models.SubmitRoundScoreRequest) (HoleScorer, error) {
+	if s.Scorer == nil || req.TeamID == nil {
+		return nil, nil
+	}
+	return s.Scorer(roundID)
+}
+
+// writeRoundScore writes a score in a transaction. With a base, the write is
+// checked against the score on the hole in the same transaction.
+func writeRoundScore(ctx context.Context, q *db.Queries, scoreHole HoleScorer, roundID int, req models.SubmitRoundScoreRequest, baseUpdatedAt *int64) (int, error) {
+	if err := checkCut(ctx, q, roundID, req.TeamID, req.PlayerID); err != nil {
+		return 0, err
+	}
+
 	// Check if score exists
 	var pid interface{}
 	if req.PlayerID != nil {
@@ -739,6 +1015,12 @@ func (s *Store) SubmitRoundScore(roundID int, req models.SubmitRoundScoreRequest
 		CourseHoleID:      int64(req.CourseHoleID),
 	})
 
+	if baseUpdatedAt != nil {
+		if err := checkScoreBase(ctx, q, id, err, *baseUpdatedAt); err != nil {
+			return 0, err
+		}
+	}
+
 	if err == sql.ErrNoRows {
 		// Insert new score
 		var pID sql.NullInt64
@@ -774,13 +1056,42 @@ func (s *Store) SubmitRoundScore(roundID int, req models.SubmitRoundScoreRequest
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, err
+	if scoreHole != nil {
+		if err := updateHoleResult(ctx, q, scoreHole, roundID, *req.TeamID, req.CourseHoleID); err != nil {
+			return 0, err
+		}
 	}
 
 	return int(id), nil
 }
 
+// checkScoreBase compares the score on a hole, as looked up with lookupErr,
+// with the version of it a client last saw
+func checkScoreBase(ctx context.Context, q *db.Queries, id int64, lookupErr error, baseUpdatedAt int64) error {
+	if lookupErr == sql.ErrNoRows {
+		if baseUpdatedAt > 0 {
+			// Deleted since the client saw it
+			return &ScoreConflictError{}
+		}
+		return nil
+	}
+	if lookupErr != nil {
+		return lookupErr
+	}
+
+	updatedAt, err := q.GetScoreUpdatedAt(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if updatedAt > baseUpdatedAt {
+		return &ScoreConflictError{ScoreID: int(id)}
+	}
+	return nil
+}
+
 // -- Team Groups --
 
 func (s *Store) CreateTeamGroup(tournamentID int, name string) (*models.TeamGroup, error) {
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// HoleScorer scores a team's hole from the team's entries on it. ok is false
+// while the hole is incomplete or not part of the round.
+type HoleScorer func(teamID int, courseHoleID int, entries []models.Score) (holeNumber int, score int, ok bool)
+
+// RoundScorer prepares a HoleScorer for a round. A nil HoleScorer means the
+// round cannot be scored yet, e.g. it has no course.
+type RoundScorer func(roundID int) (HoleScorer, error)
+
+// updateHoleResult rescores a team's hole from its entries inside a transaction
+func updateHoleResult(ctx context.Context, q *db.Queries, scoreHole HoleScorer, roundID, teamID, courseHoleID int) error {
+	rows, err := q.GetRoundScores(ctx, db.GetRoundScoresParams{
+		TournamentRoundID: int64(roundID),
+		TeamID:            int64(teamID),
+	})
+	if err != nil {
+		return err
+	}
+
+	entries := []models.Score{}
+	for _, row := range rows {
+		if int(row.CourseHoleID) == courseHoleID {
+			entries = append(entries, roundScore(row))
+		}
+	}
+
+	number, score, ok := scoreHole(teamID, courseHoleID, entries)
+	if !ok {
+		return q.DeleteTeamHoleResult(ctx, db.DeleteTeamHoleResultParams{
+			TournamentRoundID: int64(roundID),
+			TeamID:            int64(teamID),
+			CourseHoleID:      int64(courseHoleID),
+		})
+	}
+	return q.UpsertTeamHoleResult(ctx, db.UpsertTeamHoleResultParams{
+		TournamentRoundID: int64(roundID),
+		TeamID:            int64(teamID),
+		CourseHoleID:      int64(courseHoleID),
+		HoleNumber:        int64(number),
+		Score:             int64(score),
+	})
+}
+
+func (s *Store) GetRoundResults(roundID int) ([]models.TeamHoleResult, error) {
+	rows, err := s.Queries.GetRoundResults(context.Background(), int64(roundID))
+	if err != nil {
+		return nil, err
+	}
+	results := []models.TeamHoleResult{}
+	for _, r := range rows {
+		results = append(results, models.TeamHoleResult{
+			RoundID:      int(r.TournamentRoundID),
+			TeamID:       int(r.TeamID),
+			CourseHoleID: int(r.CourseHoleID),
+			HoleNumber:   int(r.HoleNumber),
+			Score:        int(r.Score),
+		})
+	}
+	return results, nil
+}
+
+// GetUnscoredRounds lists the rounds that have scores but no team hole
+// results, such as rounds scored before results were maintained
+func (s *Store) GetUnscoredRounds() ([]int, error) {
+	rows, err := s.Queries.GetUnscoredRounds(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	rounds := []int{}
+	for _, id := range rows {
+		rounds = append(rounds, int(id))
+	}
+	return rounds, nil
+}
+
+// ReplaceRoundResultsTx swaps a round's results for a freshly computed set
+func (s *Store) ReplaceRoundResultsTx(roundID int, results []models.TeamHoleResult) error {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := s.Queries.WithTx(tx)
+
+	if err := q.DeleteRoundResults(ctx, int64(roundID)); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := q.UpsertTeamHoleResult(ctx, db.UpsertTeamHoleResultParams{
+			TournamentRoundID: int64(roundID),
+			TeamID:            int64(r.TeamID),
+			CourseHoleID:      int64(r.CourseHoleID),
+			HoleNumber:        int64(r.HoleNumber),
+			Score:             int64(r.Score),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
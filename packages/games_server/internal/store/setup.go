@@ -37,6 +37,18 @@ func (s *Store) SetupTournamentTx(req models.SetupTournamentRequest) (*models.To
 		}
 	}
 
+	// The cut needs a later round for the teams that make it
+	if req.Cut != nil {
+		cutRound, laterRound := false, false
+		for _, r := range req.Rounds {
+			cutRound = cutRound || r.RoundNumber == req.Cut.AfterRound
+			laterRound = laterRound || r.RoundNumber > req.Cut.AfterRound
+		}
+		if !cutRound || !laterRound {
+			return nil, fmt.Errorf("cut after round %d must be followed by another round", req.Cut.AfterRound)
+		}
+	}
+
 	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -56,6 +68,15 @@ func (s *Store) SetupTournamentTx(req models.SetupTournamentRequest) (*models.To
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tournament: %w", err)
 	}
+	if err := setMaxScore(ctx, q, int(t.ID), req.MaxScore); err != nil {
+		return nil, err
+	}
+	if err := setTiebreak(ctx, q, int(t.ID), req.Tiebreak); err != nil {
+		return nil, err
+	}
+	if err := setCutRule(ctx, q, int(t.ID), req.Cut); err != nil {
+		return nil, err
+	}
 
 	// 3. Create Rounds
 	for _, r := range req.Rounds {
@@ -65,14 +86,24 @@ func (s *Store) SetupTournamentTx(req models.SetupTournamentRequest) (*models.To
 			status = "pending"
 		}
 
+		// NULL allowance means "use the format's default allowance"
+		var allowance sql.NullFloat64
+		if r.AwardedHandicap != nil {
+			allowance = sql.NullFloat64{Float64: *r.AwardedHandicap, Valid: true}
+		} else if req.AwardedHandicap > 0 {
+			allowance = sql.NullFloat64{Float64: req.AwardedHandicap, Valid: true}
+		}
+
 		_, err := q.CreateTournamentRound(ctx, db.CreateTournamentRoundParams{
-			TournamentID: t.ID,
-			RoundNumber:  int64(r.RoundNumber),
-			Date:         d,
-			CourseID:     int64(r.CourseID),
-			FormatID:     int64(r.FormatID),
-			Name:         r.Name,
-			Status:       sql.NullString{String: status, Valid: true},
+			TournamentID:    t.ID,
+			RoundNumber:     int64(r.RoundNumber),
+			Date:            d,
+			CourseID:        int64(r.CourseID),
+			FormatID:        int64(r.FormatID),
+			Name:            r.Name,
+			Status:          sql.NullString{String: status, Valid: true},
+			AwardedHandicap: allowance,
+			IsMatchPlay:     sql.NullBool{Bool: r.IsMatchPlay, Valid: true},
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create round %d: %w", r.RoundNumber, err)
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/patrick-salvatore/games-server/internal/models"
+	db "github.com/patrick-salvatore/games-server/models"
+)
+
+// -- Sync Clients --
+
+// SyncClient identifies a sync client. Clients pick their own IDs, so one is
+// only known within the tournament and player (0 for none) of its token.
+type SyncClient struct {
+	TournamentID int
+	PlayerID     int
+	ClientID     string
+}
+
+// GetClientSeq returns the last mutation sequence number a sync client had
+// applied, or 0 for a client not seen before
+func (s *Store) GetClientSeq(client SyncClient) (int64, error) {
+	return getClientSeq(context.Background(), s.Queries, client)
+}
+
+func getClientSeq(ctx context.Context, q *db.Queries, client SyncClient) (int64, error) {
+	seq, err := q.GetClientSeq(ctx, db.GetClientSeqParams{
+		TournamentID: int64(client.TournamentID),
+		PlayerID:     int64(client.PlayerID),
+		ClientID:     client.ClientID,
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return seq, err
+}
+
+// MutationTx is the transaction a sync client's mutation applies in. The
+// client's claim on the mutation, its result and its seq commit with the
+// write, so a retry never sees the write without its result.
+type MutationTx struct {
+	Tx *sql.Tx
+
+	s      *Store
+	q      *db.Queries
+	client SyncClient
+}
+
+// BeginMutation starts a transaction for a client's mutation. Its writes are
+// attributed to the client in the changelog.
+func (s *Store) BeginMutation(client SyncClient) (*MutationTx, error) {
+	ctx := context.Background()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	q := s.Queries.WithTx(tx)
+
+	// A retry racing the original waits on the connection's busy timeout
+	// until the original commits, then finds its result
+	if err := lockForWrite(ctx, q); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := q.SetTxContext(ctx, sql.NullString{String: client.ClientID, Valid: client.ClientID != ""}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return &MutationTx{Tx: tx, s: s, q: q, client: client}, nil
+}
+
+// GetResult returns the result recorded for the client's mutation since a
+// time, or nil if there is none
+func (m *MutationTx) GetResult(mutationID string, since time.Time) (*models.MutationResult, error) {
+	data, err := m.q.GetMutationResult(context.Background(), db.GetMutationResultParams{
+		TournamentID: int64(m.client.TournamentID),
+		PlayerID:     int64(m.client.PlayerID),
+		ClientID:     m.client.ClientID,
+		MutationID:   mutationID,
+		CreatedAt:    since.UnixMilli(),
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.MutationResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LastSeq returns the last mutation sequence number the client had applied
+func (m *MutationTx) LastSeq() (int64, error) {
+	return getClientSeq(context.Background(), m.q, m.client)
+}
+
+func (m *MutationTx) SetSeq(seq int64) error {
+	return m.q.SetClientSeq(context.Background(), db.SetClientSeqParams{
+		TournamentID: int64(m.client.TournamentID),
+		PlayerID:     int64(m.client.PlayerID),
+		ClientID:     m.client.ClientID,
+		LastSeq:      seq,
+		UpdatedAt:    time.Now().UnixMilli(),
+	})
+}
+
+func (m *MutationTx) RecordResult(mutationID string, seq int64, result models.MutationResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return m.q.InsertMutationResult(context.Background(), db.InsertMutationResultParams{
+		TournamentID: int64(m.client.TournamentID),
+		PlayerID:     int64(m.client.PlayerID),
+		ClientID:     m.client.ClientID,
+		MutationID:   mutationID,
+		Seq:          sql.NullInt64{Int64: seq, Valid: seq > 0},
+		Result:       string(data),
+		CreatedAt:    time.Now().UnixMilli(),
+	})
+}
+
+// SubmitRoundScore writes a score as Store.SubmitRoundScore does. The score
+// on the hole must not have changed since baseUpdatedAt, the version of it
+// the client last saw (0 if none), or a *ScoreConflictError is returned.
+func (m *MutationTx) SubmitRoundScore(roundID int, req models.SubmitRoundScoreRequest, baseUpdatedAt int64) (int, error) {
+	scoreHole, err := m.s.holeScorer(roundID, req)
+	if err != nil {
+		return 0, err
+	}
+	return writeRoundScore(context.Background(), m.q, scoreHole, roundID, req, &baseUpdatedAt)
+}
+
+func (m *MutationTx) Commit() error {
+	if err := m.q.ClearTxContext(context.Background()); err != nil {
+		return err
+	}
+	return m.Tx.Commit()
+}
+
+func (m *MutationTx) Rollback() error {
+	return m.Tx.Rollback()
+}
+
+// PruneMutationResults forgets the mutation results recorded before a time
+func (s *Store) PruneMutationResults(before time.Time) (int64, error) {
+	return s.Queries.DeleteMutationResults(context.Background(), before.UnixMilli())
+}
@@ -177,13 +177,15 @@ func (s *Store) GetCourseByTournamentRoundIDTx(tx *sql.Tx, tournamentRoundID int
 	var holes []models.HoleData
 	for _, h := range hRows {
 		holes = append(holes, models.HoleData{
-			ID:       int(h.ID),
-			Number:   int(h.HoleNumber),
-			Par:      int(h.Par),
-			Handicap: int(h.Handicap),
-			Yardage:  int(h.Yardage),
+			ID:          int(h.ID),
+			Number:      int(h.HoleNumber),
+			Par:         int(h.Par),
+			Handicap:    int(h.Handicap),
+			RawHandicap: int(h.Handicap),
+			Yardage:     int(h.Yardage),
 		})
 	}
+	rankStrokeIndexes(holes)
 
 	return &models.Course{
 		ID:   int(c.ID),
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/joho/godotenv"
+	"github.com/patrick-salvatore/games-server/internal/game"
 	"github.com/patrick-salvatore/games-server/internal/handlers"
 	"github.com/patrick-salvatore/games-server/internal/infra"
 	internalMiddleware "github.com/patrick-salvatore/games-server/internal/middleware"
@@ -37,6 +40,23 @@ func main() {
 	}
 
 	db := store.NewStore(sqlDB)
+	db.Scorer = game.NewRoundScorer(db)
+
+	// Rounds scored before team hole results were maintained
+	if n, err := game.BackfillRoundResults(context.Background(), db); err != nil {
+		log.Fatalf("Failed to backfill team hole results: %v", err)
+	} else if n > 0 {
+		log.Printf("Backfilled team hole results for %d rounds", n)
+	}
+
+	// Changelog Compaction
+	retention := 30 * 24 * time.Hour
+	if v := os.Getenv("CHANGELOG_RETENTION"); v != "" {
+		if retention, err = time.ParseDuration(v); err != nil {
+			log.Fatalf("Invalid CHANGELOG_RETENTION: %v", err)
+		}
+	}
+	go compactChangelog(db, retention)
 
 	// Cache Setup
 	cacheManager, err := infra.NewCacheManager()
@@ -83,6 +103,16 @@ func main() {
 		r.With(internalMiddleware.RequireAdmin).Post("/v1/tournaments", handlers.CreateTournament(db))
 		r.With(internalMiddleware.RequireAdmin).Post("/v1/tournaments/setup", handlers.SetupTournament(db))
 		r.With(internalMiddleware.RequireAdmin).Post("/v1/tournament/{id}/rounds", handlers.CreateTournamentRound(db))
+		r.With(internalMiddleware.RequireAdmin).Post("/v1/round/{roundId}/matches", handlers.SetRoundMatches(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Put("/v1/courses/{id}/tees/{teeId}", handlers.UpdateCourseTeeRating(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Put("/v1/tournament/{id}/max_score", handlers.SetTournamentMaxScore(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Post("/v1/round/{roundId}/differentials", handlers.PostRoundDifferentials(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Put("/v1/tournament/{id}/tiebreak", handlers.SetTournamentTiebreak(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Post("/v1/tournament/{id}/playoff", handlers.RecordPlayoff(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Delete("/v1/tournament/{id}/playoff", handlers.ClearPlayoffResults(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Put("/v1/tournament/{id}/cut", handlers.SetTournamentCut(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Put("/v1/round/{roundId}/status", handlers.UpdateRoundStatus(db, cacheManager))
+		r.With(internalMiddleware.RequireAdmin).Post("/v1/tournament/{id}/rewards", handlers.CreateTournamentReward(db))
 		r.With(internalMiddleware.RequireTournamentOrAdmin).Post("/v1/players", handlers.CreatePlayer(db))
 		r.With(internalMiddleware.RequireTournamentOrAdmin).Post("/v1/invites", handlers.CreateInvite(db))
 	})
@@ -93,6 +123,7 @@ func main() {
 
 		// Players
 		r.Get("/v1/players", handlers.GetPlayers(db))
+		r.Get("/v1/players/{id}/differentials", handlers.GetPlayerDifferentials(db))
 
 		// Tournaments
 		r.Get("/v1/tournaments", handlers.GetTournaments(db))
@@ -103,6 +134,8 @@ func main() {
 		r.Get("/v1/tournament/{id}/rounds", handlers.GetTournamentRounds(db))
 		r.Get("/v1/round/{roundId}", handlers.GetTournamentRound(db))
 		r.Get("/v1/round/{roundId}/course", handlers.GetCourseByTournamentRoundID(db))
+		r.Get("/v1/round/{roundId}/handicaps", handlers.GetRoundHandicaps(db))
+		r.Get("/v1/round/{roundId}/handicaps/teams", handlers.GetRoundTeamHandicaps(db))
 
 		// Teams
 		r.Get("/v1/teams/{id}", handlers.GetTeam(db))
@@ -110,6 +143,7 @@ func main() {
 
 		// Courses
 		r.Get("/v1/courses", handlers.GetCourses(db))
+		r.Get("/v1/courses/{id}/tees", handlers.GetCourseTees(db))
 
 		// Session
 		r.Get("/v1/session", handlers.GetSession)
@@ -123,16 +157,44 @@ func main() {
 
 		// Round Scores
 		r.Get("/v1/round/{roundId}/scores", handlers.GetRoundScores(db))
+		r.Get("/v1/round/{roundId}/scores/adjusted", handlers.GetRoundAdjustedScores(db))
+		r.Get("/v1/round/{roundId}/scorecard", handlers.GetRoundScorecard(db)) // ?teamId= or ?playerId=
 		r.Post("/v1/round/{roundId}/scores", handlers.SubmitRoundScore(db, cacheManager))
 
+		// Match Play
+		r.Get("/v1/round/{roundId}/matches", handlers.GetRoundMatches(db))
+
 		// Leaderboard
 		r.Get("/v1/tournament/{id}/leaderboard", handlers.GetLeaderboard(db, cacheManager))
 		r.Get("/v1/tournament/{id}/round/{roundId}/leaderboard", handlers.GetRoundLeaderboard(db, cacheManager))
+		r.Get("/v1/tournament/{id}/playoff", handlers.GetPlayoffResults(db))
+
+		// Exports, as CSV or JSON per the Accept header or ?format=
+		r.Get("/v1/tournament/{id}/leaderboard/export", handlers.ExportLeaderboard(db, cacheManager))
+		r.Get("/v1/tournament/{id}/round/{roundId}/leaderboard/export", handlers.ExportRoundLeaderboard(db, cacheManager))
+		r.Get("/v1/round/{roundId}/scorecards/export", handlers.ExportRoundScorecards(db))
+
+		// Replays from the changelog, at ?version= or ?at=
+		r.Get("/v1/tournament/{id}/leaderboard/replay", handlers.ReplayLeaderboard(db))
+		r.Get("/v1/tournament/{id}/round/{roundId}/leaderboard/replay", handlers.ReplayRoundLeaderboard(db))
+		r.Get("/v1/tournament/{id}/leaderboard/timeline", handlers.GetLeaderboardTimeline(db))
+		r.Get("/v1/tournament/{id}/round/{roundId}/leaderboard/timeline", handlers.GetRoundLeaderboardTimeline(db))
+
+		// Rewards
+		r.Get("/v1/reward_metrics", handlers.GetRewardMetrics)
+		r.Get("/v1/tournament/{id}/rewards", handlers.GetTournamentRewards(db, cacheManager))
+
+		// Stats
+		r.Get("/v1/tournament/{id}/stats", handlers.GetTournamentStats(db))
+		r.Get("/v1/tournament/{id}/stats/players", handlers.GetPlayerStats(db))
+		r.Get("/v1/tournament/{id}/stats/teams", handlers.GetTeamStats(db))
+		r.Get("/v1/tournament/{id}/stats/round/{roundId}", handlers.GetRoundStats(db))
 
 		// Sync Engine
 		r.Get("/v1/sync", handlers.Sync(db))
-		r.Get("/v1/events", handlers.Events(db))
-		r.Post("/v1/mutate", handlers.Mutate(db))
+		r.Get("/v1/sync/types", handlers.GetEntityTypes)
+		r.Get("/v1/events", handlers.Events(db, cacheManager))
+		r.Post("/v1/mutate", handlers.Mutate(db, cacheManager))
 	})
 
 	log.Println("Server starting on :8080")
@@ -140,3 +202,22 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// compactChangelog compacts the changelog past the retention window hourly,
+// and forgets mutation results past the dedupe window
+func compactChangelog(db *store.Store, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		pruned, err := db.CompactChangelog(time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("changelog compaction: %v", err)
+		} else if pruned > 0 {
+			log.Printf("changelog compaction: pruned %d changes", pruned)
+		}
+		if _, err := db.PruneMutationResults(time.Now().Add(-handlers.MutationDedupeWindow)); err != nil {
+			log.Printf("mutation results: %v", err)
+		}
+		<-ticker.C
+	}
+}
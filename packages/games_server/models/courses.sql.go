@@ -105,3 +105,93 @@ func (q *Queries) GetCourseHoles(ctx context.Context, courseID int64) ([]GetCour
 	}
 	return items, nil
 }
+
+const getCourseTees = `-- name: GetCourseTees :many
+SELECT id, course_id, name, slope, course_rating, par
+FROM course_tees
+WHERE course_id = ?
+ORDER BY id ASC
+`
+
+type GetCourseTeesRow struct {
+	ID           int64
+	CourseID     int64
+	Name         sql.NullString
+	Slope        int64
+	CourseRating sql.NullFloat64
+	Par          sql.NullInt64
+}
+
+func (q *Queries) GetCourseTees(ctx context.Context, courseID int64) ([]GetCourseTeesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCourseTees, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCourseTeesRow
+	for rows.Next() {
+		var i GetCourseTeesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CourseID,
+			&i.Name,
+			&i.Slope,
+			&i.CourseRating,
+			&i.Par,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCourseTeeRating = `-- name: UpdateCourseTeeRating :one
+UPDATE course_tees
+SET slope = ?, course_rating = ?, par = ?
+WHERE id = ? AND course_id = ?
+RETURNING id, course_id, name, slope, course_rating, par
+`
+
+type UpdateCourseTeeRatingParams struct {
+	Slope        int64
+	CourseRating sql.NullFloat64
+	Par          sql.NullInt64
+	ID           int64
+	CourseID     int64
+}
+
+type UpdateCourseTeeRatingRow struct {
+	ID           int64
+	CourseID     int64
+	Name         sql.NullString
+	Slope        int64
+	CourseRating sql.NullFloat64
+	Par          sql.NullInt64
+}
+
+func (q *Queries) UpdateCourseTeeRating(ctx context.Context, arg UpdateCourseTeeRatingParams) (UpdateCourseTeeRatingRow, error) {
+	row := q.db.QueryRowContext(ctx, updateCourseTeeRating,
+		arg.Slope,
+		arg.CourseRating,
+		arg.Par,
+		arg.ID,
+		arg.CourseID,
+	)
+	var i UpdateCourseTeeRatingRow
+	err := row.Scan(
+		&i.ID,
+		&i.CourseID,
+		&i.Name,
+		&i.Slope,
+		&i.CourseRating,
+		&i.Par,
+	)
+	return i, err
+}
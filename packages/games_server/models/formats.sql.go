@@ -12,14 +12,17 @@ import (
 )
 
 const getAllFormats = `-- name: GetAllFormats :many
-SELECT id, name, description, is_team_scoring FROM tournament_formats ORDER BY name
+SELECT id, name, code, description, is_team_scoring, point_values, team_handicap FROM tournament_formats ORDER BY name
 `
 
 type GetAllFormatsRow struct {
 	ID            int64
 	Name          string
+	Code          sql.NullString
 	Description   sql.NullString
 	IsTeamScoring sql.NullBool
+	PointValues   sql.NullString
+	TeamHandicap  sql.NullString
 }
 
 func (q *Queries) GetAllFormats(ctx context.Context) ([]GetAllFormatsRow, error) {
@@ -34,8 +37,11 @@ func (q *Queries) GetAllFormats(ctx context.Context) ([]GetAllFormatsRow, error)
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
+			&i.Code,
 			&i.Description,
 			&i.IsTeamScoring,
+			&i.PointValues,
+			&i.TeamHandicap,
 		); err != nil {
 			return nil, err
 		}
@@ -51,7 +57,7 @@ func (q *Queries) GetAllFormats(ctx context.Context) ([]GetAllFormatsRow, error)
 }
 
 const getTournamentFormats = `-- name: GetTournamentFormats :many
-SELECT tf.id, tf.name, is_team_scoring, description, tf.created_at, tr.id, tournament_id, format_id, course_id, round_number, awarded_handicap, is_match_play, date, tr.name, status, tr.created_at
+SELECT tf.id, tf.name, is_team_scoring, description, tf.created_at, code, point_values, team_handicap, tr.id, tournament_id, format_id, course_id, round_number, awarded_handicap, is_match_play, date, tr.name, status, tr.created_at
 FROM
     tournament_formats tf
     JOIN tournament_rounds tr ON tf.id = tr.format_id
@@ -66,6 +72,9 @@ type GetTournamentFormatsRow struct {
 	IsTeamScoring   sql.NullBool
 	Description     sql.NullString
 	CreatedAt       sql.NullTime
+	Code            sql.NullString
+	PointValues     sql.NullString
+	TeamHandicap    sql.NullString
 	ID_2            int64
 	TournamentID    int64
 	FormatID        int64
@@ -94,6 +103,9 @@ func (q *Queries) GetTournamentFormats(ctx context.Context, id int64) ([]GetTour
 			&i.IsTeamScoring,
 			&i.Description,
 			&i.CreatedAt,
+			&i.Code,
+			&i.PointValues,
+			&i.TeamHandicap,
 			&i.ID_2,
 			&i.TournamentID,
 			&i.FormatID,
@@ -18,6 +18,12 @@ type Changelog struct {
 	EntityID   int64
 	Op         string
 	Data       json.RawMessage
+	CreatedAt  sql.NullInt64
+}
+
+type ChangelogHorizon struct {
+	Namespace int64
+	Version   int64
 }
 
 type Course struct {
@@ -39,10 +45,13 @@ type CourseHole struct {
 }
 
 type CourseTee struct {
-	ID        int64
-	CourseID  int64
-	Name      sql.NullString
-	CreatedAt sql.NullTime
+	ID           int64
+	CourseID     int64
+	Name         sql.NullString
+	CreatedAt    sql.NullTime
+	Slope        int64
+	CourseRating sql.NullFloat64
+	Par          sql.NullInt64
 }
 
 type Entity struct {
@@ -68,6 +77,14 @@ type Meta struct {
 	Value int64
 }
 
+type MissedCut struct {
+	ID           int64
+	TournamentID int64
+	TeamID       int64
+	AfterRound   int64
+	CreatedAt    sql.NullTime
+}
+
 type Player struct {
 	ID                  int64
 	Name                string
@@ -81,6 +98,20 @@ type Player struct {
 	CreatedAt           sql.NullTime
 }
 
+type PlayoffResult struct {
+	ID           int64
+	TournamentID int64
+	TeamID       int64
+	Place        int64
+	CreatedAt    sql.NullTime
+}
+
+type RoundHandicap struct {
+	TournamentRoundID int64
+	PlayerID          int64
+	Handicap          sql.NullFloat64
+}
+
 type Score struct {
 	ID                int64
 	PlayerID          sql.NullInt64
@@ -91,6 +122,37 @@ type Score struct {
 	CreatedAt         sql.NullTime
 }
 
+type ScoreDifferential struct {
+	ID                int64
+	PlayerID          int64
+	TournamentRoundID int64
+	AdjustedGross     int64
+	CourseRating      float64
+	Slope             int64
+	Differential      float64
+	HandicapIndex     sql.NullFloat64
+	PlayedAt          time.Time
+	CreatedAt         sql.NullTime
+}
+
+type SyncClient struct {
+	TournamentID int64
+	PlayerID     int64
+	ClientID     string
+	LastSeq      int64
+	UpdatedAt    int64
+}
+
+type SyncMutation struct {
+	TournamentID int64
+	PlayerID     int64
+	ClientID     string
+	MutationID   string
+	Seq          sql.NullInt64
+	Result       string
+	CreatedAt    int64
+}
+
 type Team struct {
 	ID           int64
 	Name         string
@@ -98,14 +160,27 @@ type Team struct {
 	CreatedAt    sql.NullTime
 }
 
+type TeamHoleResult struct {
+	TournamentRoundID int64
+	TeamID            int64
+	CourseHoleID      int64
+	HoleNumber        int64
+	Score             int64
+	UpdatedAt         sql.NullTime
+}
+
 type Tournament struct {
-	ID        int64
-	Name      string
-	TeamCount int64
-	Complete  bool
-	StartDate time.Time
-	EndDate   time.Time
-	CreatedAt sql.NullTime
+	ID            int64
+	Name          string
+	TeamCount     int64
+	Complete      bool
+	StartDate     time.Time
+	EndDate       time.Time
+	CreatedAt     sql.NullTime
+	MaxScoreRule  sql.NullString
+	MaxScoreValue sql.NullInt64
+	Tiebreak      sql.NullString
+	CutRule       sql.NullString
 }
 
 type TournamentFormat struct {
@@ -114,6 +189,9 @@ type TournamentFormat struct {
 	IsTeamScoring sql.NullBool
 	Description   sql.NullString
 	CreatedAt     sql.NullTime
+	Code          sql.NullString
+	PointValues   sql.NullString
+	TeamHandicap  sql.NullString
 }
 
 type TournamentRound struct {
@@ -154,3 +232,14 @@ type TournamentReward struct {
 type TxContext struct {
 	ClientID sql.NullString
 }
+
+type RoundMatch struct {
+	ID                int64
+	TournamentRoundID int64
+	MatchNumber       int64
+	SideATeamID       int64
+	SideBTeamID       int64
+	SideAPlayerID     sql.NullInt64
+	SideBPlayerID     sql.NullInt64
+	CreatedAt         sql.NullTime
+}
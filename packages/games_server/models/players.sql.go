@@ -120,3 +120,64 @@ func (q *Queries) GetPlayer(ctx context.Context, id int64) (GetPlayerRow, error)
 	)
 	return i, err
 }
+
+const getTournamentPlayers = `-- name: GetTournamentPlayers :many
+SELECT p.id, p.name, p.handicap, p.team_id, p.course_tees_id, ct.name AS tee_name
+FROM players p
+LEFT JOIN course_tees ct ON p.course_tees_id = ct.id
+WHERE p.tournament_id = ?
+ORDER BY p.name
+`
+
+type GetTournamentPlayersRow struct {
+	ID           int64
+	Name         string
+	Handicap     sql.NullFloat64
+	TeamID       int64
+	CourseTeesID int64
+	TeeName      sql.NullString
+}
+
+func (q *Queries) GetTournamentPlayers(ctx context.Context, tournamentID int64) ([]GetTournamentPlayersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTournamentPlayers, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTournamentPlayersRow
+	for rows.Next() {
+		var i GetTournamentPlayersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Handicap,
+			&i.TeamID,
+			&i.CourseTeesID,
+			&i.TeeName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePlayerHandicap = `-- name: UpdatePlayerHandicap :exec
+UPDATE players SET handicap = ? WHERE id = ?
+`
+
+type UpdatePlayerHandicapParams struct {
+	Handicap sql.NullFloat64
+	ID       int64
+}
+
+func (q *Queries) UpdatePlayerHandicap(ctx context.Context, arg UpdatePlayerHandicapParams) error {
+	_, err := q.db.ExecContext(ctx, updatePlayerHandicap, arg.Handicap, arg.ID)
+	return err
+}
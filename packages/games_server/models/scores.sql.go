@@ -95,6 +95,18 @@ func (q *Queries) GetScoreByUniqueKey(ctx context.Context, arg GetScoreByUniqueK
 	return id, err
 }
 
+const getScoreUpdatedAt = `-- name: GetScoreUpdatedAt :one
+SELECT updated_at FROM entities
+WHERE type = 'score' AND entity_id = ?
+`
+
+func (q *Queries) GetScoreUpdatedAt(ctx context.Context, entityID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getScoreUpdatedAt, entityID)
+	var updated_at int64
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
 const getTournamentScores = `-- name: GetTournamentScores :many
 SELECT s.id, s.tournament_round_id, s.player_id, s.team_id, s.course_hole_id, s.strokes, s.created_at, ch.hole_number
 FROM scores s
@@ -20,9 +20,11 @@ INSERT INTO
         format_id,
         date,
         name,
-        status
+        status,
+        awarded_handicap,
+        is_match_play
     )
-VALUES (?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING
     id,
     tournament_id,
@@ -36,13 +38,15 @@ RETURNING
 `
 
 type CreateTournamentRoundParams struct {
-	TournamentID int64
-	RoundNumber  int64
-	CourseID     int64
-	FormatID     int64
-	Date         time.Time
-	Name         string
-	Status       sql.NullString
+	TournamentID    int64
+	RoundNumber     int64
+	CourseID        int64
+	FormatID        int64
+	Date            time.Time
+	Name            string
+	Status          sql.NullString
+	AwardedHandicap sql.NullFloat64
+	IsMatchPlay     sql.NullBool
 }
 
 type CreateTournamentRoundRow struct {
@@ -66,6 +70,8 @@ func (q *Queries) CreateTournamentRound(ctx context.Context, arg CreateTournamen
 		arg.Date,
 		arg.Name,
 		arg.Status,
+		arg.AwardedHandicap,
+		arg.IsMatchPlay,
 	)
 	var i CreateTournamentRoundRow
 	err := row.Scan(
@@ -202,6 +208,49 @@ func (q *Queries) GetActiveTournamentRound(ctx context.Context, tournamentID int
 	return i, err
 }
 
+const getCourseRounds = `-- name: GetCourseRounds :many
+SELECT id, tournament_id, format_id, course_id, round_number, awarded_handicap, is_match_play, date, name, status, created_at
+FROM tournament_rounds
+WHERE
+    course_id = ?
+ORDER BY tournament_id, round_number
+`
+
+func (q *Queries) GetCourseRounds(ctx context.Context, courseID int64) ([]TournamentRound, error) {
+	rows, err := q.db.QueryContext(ctx, getCourseRounds, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TournamentRound
+	for rows.Next() {
+		var i TournamentRound
+		if err := rows.Scan(
+			&i.ID,
+			&i.TournamentID,
+			&i.FormatID,
+			&i.CourseID,
+			&i.RoundNumber,
+			&i.AwardedHandicap,
+			&i.IsMatchPlay,
+			&i.Date,
+			&i.Name,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTournamentRound = `-- name: GetTournamentRound :one
 SELECT tr.id, tr.tournament_id, tr.format_id, tr.course_id, tr.round_number, tr.awarded_handicap, tr.is_match_play, tr.date, tr.name, tr.status, tr.created_at, c.name AS course_name
 FROM
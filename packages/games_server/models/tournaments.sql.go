@@ -28,7 +28,11 @@ RETURNING
     complete,
     start_date,
     end_date,
-    created_at
+    created_at,
+    max_score_rule,
+    max_score_value,
+    tiebreak,
+    cut_rule
 `
 
 type CreateTournamentParams struct {
@@ -56,6 +60,10 @@ func (q *Queries) CreateTournament(ctx context.Context, arg CreateTournamentPara
 		&i.StartDate,
 		&i.EndDate,
 		&i.CreatedAt,
+		&i.MaxScoreRule,
+		&i.MaxScoreValue,
+		&i.Tiebreak,
+		&i.CutRule,
 	)
 	return i, err
 }
@@ -68,7 +76,11 @@ SELECT
     complete,
     start_date,
     end_date,
-    created_at
+    created_at,
+    max_score_rule,
+    max_score_value,
+    tiebreak,
+    cut_rule
 FROM tournaments
 ORDER BY created_at DESC
 `
@@ -90,6 +102,10 @@ func (q *Queries) GetAllTournaments(ctx context.Context) ([]Tournament, error) {
 			&i.StartDate,
 			&i.EndDate,
 			&i.CreatedAt,
+			&i.MaxScoreRule,
+			&i.MaxScoreValue,
+			&i.Tiebreak,
+			&i.CutRule,
 		); err != nil {
 			return nil, err
 		}
@@ -106,7 +122,7 @@ func (q *Queries) GetAllTournaments(ctx context.Context) ([]Tournament, error) {
 
 const getTournament = `-- name: GetTournament :one
 SELECT
-    t.id, t.name, t.team_count, t.complete, t.start_date, t.end_date, t.created_at
+    t.id, t.name, t.team_count, t.complete, t.start_date, t.end_date, t.created_at, t.max_score_rule, t.max_score_value, t.tiebreak, t.cut_rule
 FROM
     tournaments t
 WHERE
@@ -124,6 +140,53 @@ func (q *Queries) GetTournament(ctx context.Context, id int64) (Tournament, erro
 		&i.StartDate,
 		&i.EndDate,
 		&i.CreatedAt,
+		&i.MaxScoreRule,
+		&i.MaxScoreValue,
+		&i.Tiebreak,
+		&i.CutRule,
 	)
 	return i, err
 }
+
+const setTournamentCutRule = `-- name: SetTournamentCutRule :exec
+UPDATE tournaments SET cut_rule = ? WHERE id = ?
+`
+
+type SetTournamentCutRuleParams struct {
+	CutRule sql.NullString
+	ID      int64
+}
+
+func (q *Queries) SetTournamentCutRule(ctx context.Context, arg SetTournamentCutRuleParams) error {
+	_, err := q.db.ExecContext(ctx, setTournamentCutRule, arg.CutRule, arg.ID)
+	return err
+}
+
+const setTournamentMaxScore = `-- name: SetTournamentMaxScore :exec
+UPDATE tournaments SET max_score_rule = ?, max_score_value = ? WHERE id = ?
+`
+
+type SetTournamentMaxScoreParams struct {
+	MaxScoreRule  sql.NullString
+	MaxScoreValue sql.NullInt64
+	ID            int64
+}
+
+func (q *Queries) SetTournamentMaxScore(ctx context.Context, arg SetTournamentMaxScoreParams) error {
+	_, err := q.db.ExecContext(ctx, setTournamentMaxScore, arg.MaxScoreRule, arg.MaxScoreValue, arg.ID)
+	return err
+}
+
+const setTournamentTiebreak = `-- name: SetTournamentTiebreak :exec
+UPDATE tournaments SET tiebreak = ? WHERE id = ?
+`
+
+type SetTournamentTiebreakParams struct {
+	Tiebreak sql.NullString
+	ID       int64
+}
+
+func (q *Queries) SetTournamentTiebreak(ctx context.Context, arg SetTournamentTiebreakParams) error {
+	_, err := q.db.ExecContext(ctx, setTournamentTiebreak, arg.Tiebreak, arg.ID)
+	return err
+}